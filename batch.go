@@ -0,0 +1,122 @@
+package funk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Batcher buffers arguments and performs a Consumer of batches when the buffer is full or the time window since the
+// first buffered argument elapses, whichever comes first. It's safe for concurrent use. The consumer of batches is
+// performed without holding the lock of the Batcher, so it doesn't block buffering and may call back into the
+// Batcher, but batches may be performed concurrently. Debouncer and Throttler deliver in the same way.
+type Batcher[T any] struct {
+	size     int
+	window   time.Duration
+	consumer Consumer[[]T]
+	clock    Clock
+
+	mu    sync.Mutex
+	buf   []T
+	ctx   context.Context
+	timer Timer
+	gen   int
+	err   error
+}
+
+// NewBatcher returns a Batcher that performs consumer on batches of at most size arguments. If window is positive,
+// batches are also performed when window elapses since their first arguments were buffered.
+func NewBatcher[T any](size int, window time.Duration, consumer Consumer[[]T]) *Batcher[T] {
+	if size <= 0 {
+		size = 1
+	}
+	return &Batcher[T]{size: size, window: window, consumer: consumer, clock: SystemClock}
+}
+
+// WithClock makes this batcher use c instead of SystemClock, it must be called before use.
+func (b *Batcher[T]) WithClock(c Clock) *Batcher[T] {
+	b.clock = c
+	return b
+}
+
+// Consumer returns a Consumer that buffers its argument. It performs the batch with the context of the argument if
+// the buffer is full, and returns the context and error of the batch.
+// The errors of batches performed because of the time window are kept until the next call of Flush or Close, which
+// returns them, so the error of a call is always about its own argument.
+// Batches performed because of the time window take the context of their first arguments without cancellation.
+func (b *Batcher[T]) Consumer() Consumer[T] {
+	return func(ctx context.Context, t T) (context.Context, error) {
+		b.mu.Lock()
+		if len(b.buf) == 0 {
+			b.ctx = context.WithoutCancel(ctx)
+			if b.window > 0 {
+				gen := b.gen
+				b.timer = b.clock.AfterFunc(b.window, func() {
+					b.mu.Lock()
+					if gen != b.gen {
+						b.mu.Unlock()
+						return
+					}
+					batch, ctx := b.take()
+					b.mu.Unlock()
+					_, err := b.perform(ctx, batch)
+					b.mu.Lock()
+					b.err = errors.Join(b.err, err)
+					b.mu.Unlock()
+				})
+			}
+		}
+		b.buf = append(b.buf, t)
+		if len(b.buf) < b.size {
+			b.mu.Unlock()
+			return ctx, nil
+		}
+		batch, _ := b.take()
+		b.mu.Unlock()
+		return b.perform(ctx, batch)
+	}
+}
+
+// Flush performs the buffered arguments as a batch with ctx, it does nothing if the buffer is empty. The error of the
+// batch is joined with the errors of the batches performed because of the time window since the last Flush or Close.
+func (b *Batcher[T]) Flush(ctx context.Context) (context.Context, error) {
+	b.mu.Lock()
+	prev := b.takeErr()
+	batch, _ := b.take()
+	b.mu.Unlock()
+	ctx, err := b.perform(ctx, batch)
+	return ctx, errors.Join(prev, err)
+}
+
+// Close is Flush with the background context, it's for deferring.
+func (b *Batcher[T]) Close() error {
+	_, err := b.Flush(context.Background())
+	return err
+}
+
+// take takes the buffered arguments and the context of the first one out of the buffer, and stops the time window.
+func (b *Batcher[T]) take() ([]T, context.Context) {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	batch, ctx := b.buf, b.ctx
+	b.buf, b.ctx = nil, nil
+	b.gen++
+	return batch, ctx
+}
+
+// perform performs the consumer on batch, it does nothing if batch is empty. It must be called without holding b.mu.
+func (b *Batcher[T]) perform(ctx context.Context, batch []T) (context.Context, error) {
+	if len(batch) == 0 {
+		return ctx, nil
+	}
+	return b.consumer(ctx, batch)
+}
+
+func (b *Batcher[T]) takeErr() error {
+	err := b.err
+	b.err = nil
+	return err
+}
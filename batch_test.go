@@ -0,0 +1,123 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Batcher", func() {
+	var mu sync.Mutex
+	var batches [][]int
+	var fail bool
+	consumer := funk.Consumer[[]int](func(ctx context.Context, batch []int) (context.Context, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, batch)
+		if fail {
+			return ctx, errors.New("")
+		}
+		return incCtxValue(ctx), nil
+	})
+	consumedBatches := func() [][]int {
+		mu.Lock()
+		defer mu.Unlock()
+		return batches
+	}
+	BeforeEach(func() {
+		batches = nil
+		fail = false
+	})
+
+	It("should perform batches when the buffer is full", func() {
+		c := funk.NewBatcher(2, 0, consumer).Consumer()
+		ctx, err := c(context.Background(), 1)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(getCtxValue(ctx)).To(Equal(0))
+		ctx, err = c(context.Background(), 2)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(getCtxValue(ctx)).To(Equal(1))
+		_, _ = c(context.Background(), 3)
+		Expect(consumedBatches()).To(Equal([][]int{{1, 2}}))
+	})
+
+	It("should perform batches when the time window elapses", func() {
+		clock := funk.NewManualClock(time.Unix(0, 0))
+		b := funk.NewBatcher(10, 20*time.Second, consumer).WithClock(clock)
+		c := b.Consumer()
+		_, _ = c(context.Background(), 1)
+		clock.Advance(10 * time.Second)
+		_, _ = c(context.Background(), 2)
+		clock.Advance(9 * time.Second)
+		Expect(consumedBatches()).To(BeEmpty())
+		clock.Advance(time.Second)
+		Expect(consumedBatches()).To(Equal([][]int{{1, 2}}))
+		_, _ = c(context.Background(), 3)
+		clock.Advance(20 * time.Second)
+		Expect(consumedBatches()).To(Equal([][]int{{1, 2}, {3}}))
+		Expect(b.Close()).To(Succeed())
+	})
+
+	It("should not perform batches of stale time windows", func() {
+		clock := funk.NewManualClock(time.Unix(0, 0))
+		c := funk.NewBatcher(2, 50*time.Second, consumer).WithClock(clock).Consumer()
+		_, _ = c(context.Background(), 1)
+		_, _ = c(context.Background(), 2)
+		clock.Advance(30 * time.Second)
+		_, _ = c(context.Background(), 3)
+		clock.Advance(30 * time.Second)
+		Expect(consumedBatches()).To(Equal([][]int{{1, 2}}))
+		clock.Advance(20 * time.Second)
+		Expect(consumedBatches()).To(Equal([][]int{{1, 2}, {3}}))
+		Expect(clock.Pending()).To(Equal(0))
+	})
+
+	It("should flush the rest on close", func() {
+		b := funk.NewBatcher(10, time.Hour, consumer)
+		_, _ = b.Consumer()(context.Background(), 1)
+		Expect(b.Close()).To(Succeed())
+		Expect(consumedBatches()).To(Equal([][]int{{1}}))
+		Expect(b.Close()).To(Succeed())
+		Expect(consumedBatches()).To(HaveLen(1))
+	})
+
+	It("should report errors of timed batches by Flush", func() {
+		fail = true
+		clock := funk.NewManualClock(time.Unix(0, 0))
+		b := funk.NewBatcher(10, 10*time.Second, consumer).WithClock(clock)
+		_, _ = b.Consumer()(context.Background(), 1)
+		clock.Advance(10 * time.Second)
+		Expect(consumedBatches()).To(HaveLen(1))
+		fail = false
+		_, err := b.Consumer()(context.Background(), 2)
+		Expect(err).To(Not(HaveOccurred()))
+		_, err = b.Flush(context.Background())
+		Expect(err).To(HaveOccurred())
+		_, err = b.Flush(context.Background())
+		Expect(err).To(Not(HaveOccurred()))
+	})
+
+	It("should not hold the lock while performing batches", func() {
+		var b *funk.Batcher[int]
+		var flushed []int
+		b = funk.NewBatcher(2, 0, funk.Consumer[[]int](func(ctx context.Context, batch []int) (context.Context, error) {
+			flushed = append(flushed, batch...)
+			if batch[0] == 1 {
+				return b.Consumer()(ctx, 3)
+			}
+			return ctx, nil
+		}))
+		c := b.Consumer()
+		_, _ = c(context.Background(), 1)
+		_, err := c(context.Background(), 2)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(b.Close()).To(Succeed())
+		Expect(flushed).To(Equal([]int{1, 2, 3}))
+	})
+})
@@ -0,0 +1,121 @@
+package funk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrScopeClosed is returned by Scope when resources are acquired after the scope is closed.
+var ErrScopeClosed = errors.New("scope closed")
+
+// Bracket returns a Supplier that acquires a resource, applies use to it and releases it. Release is always
+// performed once the resource is acquired, even if use returns error or panics, and the panic is propagated after
+// releasing. The error of release is joined with the error of use.
+// Release is performed with the context returned by use without cancellation, so a cancelled context doesn't
+// prevent the resource from being released, and the context returned by release is discarded.
+func Bracket[R, T any](acquire Supplier[R], use Func[R, T], release Consumer[R]) Supplier[T] {
+	return func(ctx context.Context) (context.Context, T, error) {
+		ctx, r, err := acquire(ctx)
+		if err != nil {
+			var t T
+			return ctx, t, err
+		}
+		released := false
+		defer func() {
+			if !released {
+				_, _ = release(context.WithoutCancel(ctx), r)
+			}
+		}()
+		ctx, t, err := use(ctx, r)
+		released = true
+		_, rerr := release(context.WithoutCancel(ctx), r)
+		return ctx, t, errors.Join(err, rerr)
+	}
+}
+
+// Using is Bracket that releases the resource by closing it.
+func Using[R io.Closer, T any](acquire Supplier[R], use Func[R, T]) Supplier[T] {
+	return Bracket(acquire, use, closeResource[R])
+}
+
+// Scope collects the releases of multiple resources and performs them in LIFO order when closed.
+// It's safe for concurrent use.
+type Scope struct {
+	mu       sync.Mutex
+	releases []func(context.Context) error
+	closed   bool
+}
+
+// NewScope returns an empty Scope.
+func NewScope() *Scope {
+	return &Scope{}
+}
+
+// Defer registers release to be performed when this scope is closed. If the scope is already closed, release is
+// performed immediately with the background context and its error is returned.
+func (s *Scope) Defer(release func(context.Context) error) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return errors.Join(ErrScopeClosed, release(context.Background()))
+	}
+	s.releases = append(s.releases, release)
+	s.mu.Unlock()
+	return nil
+}
+
+// Close performs the registered releases in LIFO order with ctx without cancellation, and returns their joined
+// errors. Releases are performed only once, and closing a closed scope does nothing.
+func (s *Scope) Close(ctx context.Context) error {
+	s.mu.Lock()
+	releases := s.releases
+	s.releases = nil
+	s.closed = true
+	s.mu.Unlock()
+
+	ctx = context.WithoutCancel(ctx)
+	var errs []error
+	for i := len(releases) - 1; i >= 0; i-- {
+		if err := releases[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Acquire returns a Supplier that acquires a resource and registers its release to s. If s is already closed, the
+// resource is released immediately and ErrScopeClosed is returned.
+func Acquire[R any](s *Scope, acquire Supplier[R], release Consumer[R]) Supplier[R] {
+	return func(ctx context.Context) (context.Context, R, error) {
+		ctx, r, err := acquire(ctx)
+		if err != nil {
+			return ctx, r, err
+		}
+		err = s.Defer(func(ctx context.Context) error {
+			_, err := release(ctx, r)
+			return err
+		})
+		return ctx, r, err
+	}
+}
+
+// AcquireCloser is Acquire that releases the resource by closing it.
+func AcquireCloser[R io.Closer](s *Scope, acquire Supplier[R]) Supplier[R] {
+	return Acquire(s, acquire, closeResource[R])
+}
+
+// Scoped returns a Supplier that applies use to a new Scope and closes the scope afterwards, as Bracket does.
+func Scoped[T any](use Func[*Scope, T]) Supplier[T] {
+	acquire := func(ctx context.Context) (context.Context, *Scope, error) {
+		return ctx, NewScope(), nil
+	}
+	return Bracket(acquire, use, func(ctx context.Context, s *Scope) (context.Context, error) {
+		return ctx, s.Close(ctx)
+	})
+}
+
+func closeResource[R io.Closer](ctx context.Context, r R) (context.Context, error) {
+	return ctx, r.Close()
+}
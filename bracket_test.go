@@ -0,0 +1,122 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+type resource struct {
+	name   string
+	closed *[]string
+	err    error
+}
+
+func (r *resource) Close() error {
+	*r.closed = append(*r.closed, r.name)
+	return r.err
+}
+
+var _ = Describe("Bracket", func() {
+	var closed []string
+	acquire := func(name string, err error) funk.Supplier[*resource] {
+		return func(ctx context.Context) (context.Context, *resource, error) {
+			return incCtxValue(ctx), &resource{name: name, closed: &closed, err: err}, nil
+		}
+	}
+	use := func(err error) funk.Func[*resource, string] {
+		return func(ctx context.Context, r *resource) (context.Context, string, error) {
+			return incCtxValue(ctx), r.name, err
+		}
+	}
+	BeforeEach(func() {
+		closed = nil
+	})
+
+	It("should release the resource after use", func() {
+		ctx, v, err := funk.Using(acquire("a", nil), use(nil))(context.Background())
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(v).To(Equal("a"))
+		Expect(closed).To(Equal([]string{"a"}))
+		Expect(getCtxValue(ctx)).To(Equal(2))
+	})
+	It("should join the errors of use and release", func() {
+		e1, e2 := errors.New("use"), errors.New("release")
+		_, _, err := funk.Using(acquire("a", e2), use(e1))(context.Background())
+		Expect(err).To(MatchError(e1))
+		Expect(err).To(MatchError(e2))
+		Expect(closed).To(Equal([]string{"a"}))
+	})
+	It("should release the resource if use panics", func() {
+		s := funk.Using(acquire("a", nil), func(ctx context.Context, r *resource) (context.Context, string, error) {
+			panic("boom")
+		})
+		Expect(func() { s(context.Background()) }).To(PanicWith("boom"))
+		Expect(closed).To(Equal([]string{"a"}))
+	})
+	It("should not release if acquire fails", func() {
+		released := false
+		s := funk.Bracket(funk.Failing[int](errors.New("")), funk.PureMustFunc[int, int](func(i int) int {
+			return i
+		}).Lift(), func(ctx context.Context, i int) (context.Context, error) {
+			released = true
+			return ctx, nil
+		})
+		_, _, err := s(context.Background())
+		Expect(err).To(HaveOccurred())
+		Expect(released).To(BeFalse())
+	})
+	It("should release with an uncancelled context", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var releaseErr error
+		s := funk.Bracket(funk.Constant(1), funk.PureMustFunc[int, int](func(i int) int {
+			return i
+		}).Lift(), func(ctx context.Context, i int) (context.Context, error) {
+			releaseErr = ctx.Err()
+			return ctx, nil
+		})
+		_, _, _ = s(ctx)
+		Expect(releaseErr).To(Not(HaveOccurred()))
+	})
+
+	Describe("Scope", func() {
+		It("should release resources in LIFO order", func() {
+			s := funk.Scoped(func(ctx context.Context, s *funk.Scope) (context.Context, string, error) {
+				ctx, a, err := funk.AcquireCloser(s, acquire("a", nil))(ctx)
+				if err != nil {
+					return ctx, "", err
+				}
+				ctx, b, err := funk.AcquireCloser(s, acquire("b", nil))(ctx)
+				return ctx, a.name + b.name, err
+			})
+			_, v, err := s(context.Background())
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal("ab"))
+			Expect(closed).To(Equal([]string{"b", "a"}))
+		})
+		It("should release all resources and join errors", func() {
+			e1, e2 := errors.New("a"), errors.New("b")
+			s := funk.NewScope()
+			_, _, _ = funk.AcquireCloser(s, acquire("a", e1))(context.Background())
+			_, _, _ = funk.AcquireCloser(s, acquire("b", e2))(context.Background())
+			err := s.Close(context.Background())
+			Expect(err).To(MatchError(e1))
+			Expect(err).To(MatchError(e2))
+			Expect(closed).To(Equal([]string{"b", "a"}))
+			Expect(s.Close(context.Background())).To(Succeed())
+			Expect(closed).To(HaveLen(2))
+		})
+		It("should release immediately after closed", func() {
+			s := funk.NewScope()
+			Expect(s.Close(context.Background())).To(Succeed())
+			_, _, err := funk.AcquireCloser(s, acquire("a", nil))(context.Background())
+			Expect(err).To(MatchError(funk.ErrScopeClosed))
+			Expect(closed).To(Equal([]string{"a"}))
+		})
+	})
+})
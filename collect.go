@@ -0,0 +1,188 @@
+package funk
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// collect performs all steps in order, threading the context through the steps that succeed, and returns the joined
+// errors of the steps that fail. Each error is wrapped as StageError named by the index of its step, unless it's
+// already a StageError, e.g. of a step performed as a stage. The result is true if all steps succeed with true.
+func collect(ctx context.Context, steps []evaluation) (context.Context, bool, error) {
+	parent := StagePath(ctx)
+	all := true
+	var errs []error
+	for i, step := range steps {
+		next, v, err := step(ctx)
+		if err != nil {
+			name := strconv.Itoa(i)
+			path := name
+			if parent != "" {
+				path = parent + "/" + name
+			}
+			errs = append(errs, wrapStage(name, path, err))
+			all = false
+			continue
+		}
+		ctx, all = next, all && v
+	}
+	return ctx, all, errors.Join(errs...)
+}
+
+func collectConsumers(ctx context.Context, steps []func(context.Context) (context.Context, error)) (context.Context, error) {
+	evals := make([]evaluation, len(steps))
+	for i, step := range steps {
+		step := step
+		evals[i] = func(ctx context.Context) (context.Context, bool, error) {
+			ctx, err := step(ctx)
+			return ctx, true, err
+		}
+	}
+	ctx, _, err := collect(ctx, evals)
+	return ctx, err
+}
+
+func collectPredicates(ctx context.Context, steps []evaluation) (context.Context, bool, error) {
+	return explainNode(ctx, "collectingAllOf", func(ctx context.Context) (context.Context, bool, error) {
+		operands := make([]evaluation, len(steps))
+		for i, step := range steps {
+			step := step
+			operands[i] = func(ctx context.Context) (context.Context, bool, error) {
+				return explainOperand(ctx, step)
+			}
+		}
+		return collect(ctx, operands)
+	})
+}
+
+// Collecting returns a Consumer that performs all consumers in sequence on the same argument, regardless of errors.
+// The context is threaded through the consumers that succeed, and the errors are joined, each wrapped as StageError
+// named by the index of its consumer unless it's already a StageError.
+func Collecting[T any](cs ...Consumer[T]) Consumer[T] {
+	return func(ctx context.Context, t T) (context.Context, error) {
+		steps := make([]func(context.Context) (context.Context, error), len(cs))
+		for i, c := range cs {
+			c := c
+			steps[i] = func(ctx context.Context) (context.Context, error) {
+				return c(ctx, t)
+			}
+		}
+		return collectConsumers(ctx, steps)
+	}
+}
+
+// PureCollecting is Collecting for PureConsumer.
+func PureCollecting[T any](cs ...PureConsumer[T]) PureConsumer[T] {
+	lifted := make([]Consumer[T], len(cs))
+	for i, c := range cs {
+		lifted[i] = c.Lift()
+	}
+	return Collecting(lifted...).Pure()
+}
+
+// BiCollecting is Collecting for BiConsumer.
+func BiCollecting[T, U any](cs ...BiConsumer[T, U]) BiConsumer[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, error) {
+		steps := make([]func(context.Context) (context.Context, error), len(cs))
+		for i, c := range cs {
+			c := c
+			steps[i] = func(ctx context.Context) (context.Context, error) {
+				return c(ctx, t, u)
+			}
+		}
+		return collectConsumers(ctx, steps)
+	}
+}
+
+// PureBiCollecting is Collecting for PureBiConsumer.
+func PureBiCollecting[T, U any](cs ...PureBiConsumer[T, U]) PureBiConsumer[T, U] {
+	lifted := make([]BiConsumer[T, U], len(cs))
+	for i, c := range cs {
+		lifted[i] = c.Lift()
+	}
+	return BiCollecting(lifted...).Pure()
+}
+
+// ThenAll returns a composed Consumer that performs this operation followed by the after operations as Collecting
+// does, so all operations are performed regardless of errors.
+func (c Consumer[T]) ThenAll(after ...Consumer[T]) Consumer[T] {
+	return Collecting(append([]Consumer[T]{c}, after...)...)
+}
+
+// ThenAll returns a composed PureConsumer that performs this operation followed by the after operations as
+// PureCollecting does.
+func (c PureConsumer[T]) ThenAll(after ...PureConsumer[T]) PureConsumer[T] {
+	return PureCollecting(append([]PureConsumer[T]{c}, after...)...)
+}
+
+// ThenAll returns a composed BiConsumer that performs this operation followed by the after operations as BiCollecting
+// does.
+func (c BiConsumer[T, U]) ThenAll(after ...BiConsumer[T, U]) BiConsumer[T, U] {
+	return BiCollecting(append([]BiConsumer[T, U]{c}, after...)...)
+}
+
+// ThenAll returns a composed PureBiConsumer that performs this operation followed by the after operations as
+// PureBiCollecting does.
+func (c PureBiConsumer[T, U]) ThenAll(after ...PureBiConsumer[T, U]) PureBiConsumer[T, U] {
+	return PureBiCollecting(append([]PureBiConsumer[T, U]{c}, after...)...)
+}
+
+// CollectingAllOf returns a composed Predicate that represents a non-short-circuiting logical AND of all predicates.
+// It evaluates all predicates regardless of results and errors, threading the context through the predicates that
+// succeed, and returns the joined errors as Collecting does. It evaluates to true if all predicates evaluate to true
+// without error.
+func CollectingAllOf[T any](ps ...Predicate[T]) Predicate[T] {
+	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		steps := make([]evaluation, len(ps))
+		for i, p := range ps {
+			steps[i] = p.bind(t)
+		}
+		return collectPredicates(ctx, steps)
+	}
+}
+
+// PureCollectingAllOf is CollectingAllOf for PurePredicate.
+func PureCollectingAllOf[T any](ps ...PurePredicate[T]) PurePredicate[T] {
+	return CollectingAllOf(liftPurePredicates(ps)...).Pure()
+}
+
+// BiCollectingAllOf is CollectingAllOf for BiPredicate.
+func BiCollectingAllOf[T, U any](ps ...BiPredicate[T, U]) BiPredicate[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, bool, error) {
+		steps := make([]evaluation, len(ps))
+		for i, p := range ps {
+			steps[i] = p.bind(t, u)
+		}
+		return collectPredicates(ctx, steps)
+	}
+}
+
+// PureBiCollectingAllOf is CollectingAllOf for PureBiPredicate.
+func PureBiCollectingAllOf[T, U any](ps ...PureBiPredicate[T, U]) PureBiPredicate[T, U] {
+	return BiCollectingAllOf(liftPureBiPredicates(ps)...).Pure()
+}
+
+// AndAll returns a composed Predicate that represents a logical AND of this predicate and the others as
+// CollectingAllOf does, so all predicates are evaluated regardless of results and errors.
+func (p Predicate[T]) AndAll(others ...Predicate[T]) Predicate[T] {
+	return CollectingAllOf(append([]Predicate[T]{p}, others...)...)
+}
+
+// AndAll returns a composed PurePredicate that represents a logical AND of this predicate and the others as
+// PureCollectingAllOf does.
+func (p PurePredicate[T]) AndAll(others ...PurePredicate[T]) PurePredicate[T] {
+	return PureCollectingAllOf(append([]PurePredicate[T]{p}, others...)...)
+}
+
+// AndAll returns a composed BiPredicate that represents a logical AND of this predicate and the others as
+// BiCollectingAllOf does.
+func (p BiPredicate[T, U]) AndAll(others ...BiPredicate[T, U]) BiPredicate[T, U] {
+	return BiCollectingAllOf(append([]BiPredicate[T, U]{p}, others...)...)
+}
+
+// AndAll returns a composed PureBiPredicate that represents a logical AND of this predicate and the others as
+// PureBiCollectingAllOf does.
+func (p PureBiPredicate[T, U]) AndAll(others ...PureBiPredicate[T, U]) PureBiPredicate[T, U] {
+	return PureBiCollectingAllOf(append([]PureBiPredicate[T, U]{p}, others...)...)
+}
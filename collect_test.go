@@ -0,0 +1,102 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Collecting", func() {
+	errBoom := errors.New("boom")
+	inc := funk.Consumer[int](func(ctx context.Context, i int) (context.Context, error) {
+		return incCtxValue(ctx), nil
+	})
+	failing := funk.Consumer[int](func(ctx context.Context, i int) (context.Context, error) {
+		return incCtxValue(incCtxValue(ctx)), errBoom
+	})
+
+	Describe("Consumer", func() {
+		It("should perform all consumers and join the errors with indices", func() {
+			ctx, err := funk.Collecting(failing, inc, failing, inc)(context.Background(), 1)
+			Expect(err).To(MatchError("stage 0: boom\nstage 2: boom"))
+			Expect(err).To(MatchError(errBoom))
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
+		It("should keep the names of named consumers", func() {
+			c := inc.ThenAll(failing.Stage("notify"), failing)
+			_, err := c.Stage("fanout")(context.Background(), 1)
+			Expect(err).To(MatchError("stage fanout/notify: boom\nstage fanout/2: boom"))
+			var se *funk.StageError
+			Expect(errors.As(err, &se)).To(BeTrue())
+			Expect(se.Name).To(Equal("notify"))
+		})
+		It("should succeed if all consumers succeed", func() {
+			ctx, err := inc.ThenAll(inc)(context.Background(), 1)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
+		It("should collect pure and bi consumers", func() {
+			var performed []int
+			pure := funk.PureConsumer[int](func(i int) error {
+				performed = append(performed, i)
+				return errBoom
+			})
+			Expect(pure.ThenAll(pure)(1)).To(MatchError("stage 0: boom\nstage 1: boom"))
+			Expect(performed).To(Equal([]int{1, 1}))
+
+			bi := funk.BiConsumer[int, int](func(ctx context.Context, i, j int) (context.Context, error) {
+				return ctx, errBoom
+			})
+			_, err := bi.ThenAll(funk.PureMustBiConsumer[int, int](func(int, int) {}).Lift(), bi)(context.Background(), 1, 2)
+			Expect(err).To(MatchError("stage 0: boom\nstage 2: boom"))
+			Expect(funk.PureBiCollecting[int, int]()(1, 2)).To(Succeed())
+		})
+	})
+
+	Describe("Predicate", func() {
+		positive := funk.PureMustPredicate[int](func(i int) bool { return i > 0 }).Lift()
+		even := funk.PureMustPredicate[int](func(i int) bool { return i%2 == 0 }).Lift()
+		broken := funk.Predicate[int](func(ctx context.Context, i int) (context.Context, bool, error) {
+			return ctx, false, errBoom
+		})
+
+		It("should evaluate all predicates", func() {
+			var evaluated int
+			counting := funk.Predicate[int](func(ctx context.Context, i int) (context.Context, bool, error) {
+				evaluated++
+				return incCtxValue(ctx), true, nil
+			})
+			ctx, v, err := funk.CollectingAllOf(even, counting, counting)(context.Background(), 1)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(BeFalse())
+			Expect(evaluated).To(Equal(2))
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
+		It("should join the errors", func() {
+			_, v, err := positive.AndAll(broken, even, broken.Stage("b"))(context.Background(), 2)
+			Expect(v).To(BeFalse())
+			Expect(err).To(MatchError("stage 1: boom\nstage b: boom"))
+		})
+		It("should be explained", func() {
+			_, e := positive.AndAll(broken, even).Explain(context.Background(), -2)
+			Expect(e.String()).To(Equal(`collectingAllOf: false (error: stage 1: boom)
+  <anonymous>: false
+  <anonymous>: false (error: boom)
+  <anonymous>: true
+`))
+		})
+		It("should collect pure and bi predicates", func() {
+			pure := funk.PurePredicate[int](func(i int) (bool, error) { return i > 0, nil })
+			Expect(pure.AndAll(pure)(1)).To(BeTrue())
+
+			bi := funk.PureMustBiPredicate[int, int](func(i, j int) bool { return i < j }).Lift()
+			_, v, err := bi.AndAll(bi)(context.Background(), 1, 2)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(BeTrue())
+		})
+	})
+})
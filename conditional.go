@@ -0,0 +1,129 @@
+package funk
+
+import "context"
+
+// When returns a Unary that applies u if p evaluates to true, and returns the argument untouched otherwise.
+// The context returned by p is passed to u, or returned if u is not applied.
+func When[T any](p Predicate[T], u Unary[T]) Unary[T] {
+	return func(ctx context.Context, t T) (context.Context, T, error) {
+		ctx, v, err := p(ctx, t)
+		if err != nil || !v {
+			return ctx, t, err
+		}
+		return u(ctx, t)
+	}
+}
+
+// Unless returns a Unary that applies u if p evaluates to false, and returns the argument untouched otherwise.
+// The context returned by p is passed to u, or returned if u is not applied.
+func Unless[T any](p Predicate[T], u Unary[T]) Unary[T] {
+	return When(p.Not(), u)
+}
+
+// IfElse returns a Func that applies then if p evaluates to true, and applies otherwise if not.
+// The context returned by p is passed to the applied function.
+func IfElse[T, R any](p Predicate[T], then, otherwise Func[T, R]) Func[T, R] {
+	return func(ctx context.Context, t T) (context.Context, R, error) {
+		ctx, v, err := p(ctx, t)
+		if err != nil {
+			var r R
+			return ctx, r, err
+		}
+		if v {
+			return then(ctx, t)
+		}
+		return otherwise(ctx, t)
+	}
+}
+
+// ConsumeIf returns a Consumer that performs c if p evaluates to true, and does nothing otherwise.
+// The context returned by p is passed to c, or returned if c is not performed.
+func ConsumeIf[T any](p Predicate[T], c Consumer[T]) Consumer[T] {
+	return func(ctx context.Context, t T) (context.Context, error) {
+		ctx, v, err := p(ctx, t)
+		if err != nil || !v {
+			return ctx, err
+		}
+		return c(ctx, t)
+	}
+}
+
+// Filter returns a Consumer that performs this operation only on the arguments that p evaluates to true.
+func (c Consumer[T]) Filter(p Predicate[T]) Consumer[T] {
+	return ConsumeIf(p, c)
+}
+
+// MustWhen is When for MustUnary.
+func MustWhen[T any](p MustPredicate[T], u MustUnary[T]) MustUnary[T] {
+	return When(p.Lift(), u.Lift()).Must()
+}
+
+// MustUnless is Unless for MustUnary.
+func MustUnless[T any](p MustPredicate[T], u MustUnary[T]) MustUnary[T] {
+	return Unless(p.Lift(), u.Lift()).Must()
+}
+
+// MustIfElse is IfElse for MustFunc.
+func MustIfElse[T, R any](p MustPredicate[T], then, otherwise MustFunc[T, R]) MustFunc[T, R] {
+	return IfElse(p.Lift(), then.Lift(), otherwise.Lift()).Must()
+}
+
+// MustConsumeIf is ConsumeIf for MustConsumer.
+func MustConsumeIf[T any](p MustPredicate[T], c MustConsumer[T]) MustConsumer[T] {
+	return ConsumeIf(p.Lift(), c.Lift()).Must()
+}
+
+// Filter returns a MustConsumer that performs this operation only on the arguments that p evaluates to true.
+func (c MustConsumer[T]) Filter(p MustPredicate[T]) MustConsumer[T] {
+	return MustConsumeIf(p, c)
+}
+
+// PureWhen is When for PureUnary.
+func PureWhen[T any](p PurePredicate[T], u PureUnary[T]) PureUnary[T] {
+	return When(p.Lift(), u.Lift()).Pure()
+}
+
+// PureUnless is Unless for PureUnary.
+func PureUnless[T any](p PurePredicate[T], u PureUnary[T]) PureUnary[T] {
+	return Unless(p.Lift(), u.Lift()).Pure()
+}
+
+// PureIfElse is IfElse for PureFunc.
+func PureIfElse[T, R any](p PurePredicate[T], then, otherwise PureFunc[T, R]) PureFunc[T, R] {
+	return IfElse(p.Lift(), then.Lift(), otherwise.Lift()).Pure()
+}
+
+// PureConsumeIf is ConsumeIf for PureConsumer.
+func PureConsumeIf[T any](p PurePredicate[T], c PureConsumer[T]) PureConsumer[T] {
+	return ConsumeIf(p.Lift(), c.Lift()).Pure()
+}
+
+// Filter returns a PureConsumer that performs this operation only on the arguments that p evaluates to true.
+func (c PureConsumer[T]) Filter(p PurePredicate[T]) PureConsumer[T] {
+	return PureConsumeIf(p, c)
+}
+
+// PureMustWhen is When for PureMustUnary.
+func PureMustWhen[T any](p PureMustPredicate[T], u PureMustUnary[T]) PureMustUnary[T] {
+	return When(p.Lift(), u.Lift()).Must().Pure()
+}
+
+// PureMustUnless is Unless for PureMustUnary.
+func PureMustUnless[T any](p PureMustPredicate[T], u PureMustUnary[T]) PureMustUnary[T] {
+	return Unless(p.Lift(), u.Lift()).Must().Pure()
+}
+
+// PureMustIfElse is IfElse for PureMustFunc.
+func PureMustIfElse[T, R any](p PureMustPredicate[T], then, otherwise PureMustFunc[T, R]) PureMustFunc[T, R] {
+	return IfElse(p.Lift(), then.Lift(), otherwise.Lift()).Must().Pure()
+}
+
+// PureMustConsumeIf is ConsumeIf for PureMustConsumer.
+func PureMustConsumeIf[T any](p PureMustPredicate[T], c PureMustConsumer[T]) PureMustConsumer[T] {
+	return ConsumeIf(p.Lift(), c.Lift()).Must().Pure()
+}
+
+// Filter returns a PureMustConsumer that performs this operation only on the arguments that p evaluates to true.
+func (c PureMustConsumer[T]) Filter(p PureMustPredicate[T]) PureMustConsumer[T] {
+	return PureMustConsumeIf(p, c)
+}
@@ -0,0 +1,122 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Conditional combinators", func() {
+	positive := funk.Predicate[int](func(ctx context.Context, i int) (context.Context, bool, error) {
+		return incCtxValue(ctx), i > 0, nil
+	})
+	failing := funk.Predicate[int](func(ctx context.Context, i int) (context.Context, bool, error) {
+		return ctx, true, errors.New("")
+	})
+	double := funk.Unary[int](func(ctx context.Context, i int) (context.Context, int, error) {
+		return incCtxValue(ctx), i * 2, nil
+	})
+
+	Describe("When and Unless", func() {
+		It("should apply the unary if the predicate holds", func() {
+			ctx, v, err := funk.When(positive, double)(context.Background(), 2)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal(4))
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
+		It("should pass the argument through if the predicate doesn't hold", func() {
+			ctx, v, err := funk.When(positive, double)(context.Background(), -2)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal(-2))
+			Expect(getCtxValue(ctx)).To(Equal(1))
+		})
+		It("should apply the unary unless the predicate holds", func() {
+			_, v, _ := funk.Unless(positive, double)(context.Background(), -2)
+			Expect(v).To(Equal(-4))
+			_, v, _ = funk.Unless(positive, double)(context.Background(), 2)
+			Expect(v).To(Equal(2))
+		})
+		It("should return errors of the predicate", func() {
+			_, v, err := funk.When(failing, double)(context.Background(), 2)
+			Expect(err).To(HaveOccurred())
+			Expect(v).To(Equal(2))
+		})
+		It("should support other variants", func() {
+			_, v := funk.MustWhen(positive.Must(), double.Must())(context.Background(), 3)
+			Expect(v).To(Equal(6))
+			v, err := funk.PureUnless(positive.Pure(), double.Pure())(3)
+			Expect(v).To(Equal(3))
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(funk.PureMustWhen(funk.Gt(0), func(i int) int { return i + 1 })(1)).To(Equal(2))
+			Expect(funk.PureMustUnless(funk.Gt(0), func(i int) int { return i + 1 })(1)).To(Equal(1))
+		})
+	})
+
+	Describe("IfElse", func() {
+		sign := funk.IfElse(positive, func(ctx context.Context, i int) (context.Context, string, error) {
+			return incCtxValue(ctx), "positive", nil
+		}, func(ctx context.Context, i int) (context.Context, string, error) {
+			return ctx, "non-positive", nil
+		})
+
+		It("should apply the selected branch with the context of the predicate", func() {
+			ctx, v, _ := sign(context.Background(), 1)
+			Expect(v).To(Equal("positive"))
+			Expect(getCtxValue(ctx)).To(Equal(2))
+			ctx, v, _ = sign(context.Background(), 0)
+			Expect(v).To(Equal("non-positive"))
+			Expect(getCtxValue(ctx)).To(Equal(1))
+		})
+		It("should return errors of the predicate", func() {
+			_, _, err := funk.IfElse(failing, sign, sign)(context.Background(), 1)
+			Expect(err).To(HaveOccurred())
+		})
+		It("should support other variants", func() {
+			_, v := funk.MustIfElse(positive.Must(), sign.Must(), sign.Must())(context.Background(), 1)
+			Expect(v).To(Equal("positive"))
+			v, _ = funk.PureIfElse(positive.Pure(), sign.Pure(), sign.Pure())(-1)
+			Expect(v).To(Equal("non-positive"))
+			Expect(funk.PureMustIfElse(funk.Eq(0), func(int) string { return "zero" },
+				func(int) string { return "non-zero" })(0)).To(Equal("zero"))
+		})
+	})
+
+	Describe("ConsumeIf and Filter", func() {
+		var consumed []int
+		var c funk.Consumer[int]
+		BeforeEach(func() {
+			consumed = nil
+			c = func(ctx context.Context, i int) (context.Context, error) {
+				consumed = append(consumed, i)
+				return incCtxValue(ctx), nil
+			}
+		})
+
+		It("should consume only the arguments the predicate holds", func() {
+			f := c.Filter(positive)
+			for _, i := range []int{1, -1, 2} {
+				_, _ = f(context.Background(), i)
+			}
+			Expect(consumed).To(Equal([]int{1, 2}))
+			ctx, _ := funk.ConsumeIf(positive, c)(context.Background(), 1)
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
+		It("should return errors of the predicate", func() {
+			_, err := funk.ConsumeIf(failing, c)(context.Background(), 1)
+			Expect(err).To(HaveOccurred())
+			Expect(consumed).To(BeEmpty())
+		})
+		It("should support other variants", func() {
+			ctx := c.Must().Filter(positive.Must())(context.Background(), 1)
+			Expect(getCtxValue(ctx)).To(Equal(2))
+			Expect(c.Pure().Filter(positive.Pure())(-1)).To(Succeed())
+			c.Must().Pure().Filter(funk.Gt(1))(2)
+			funk.PureMustConsumeIf(funk.Gt(1), c.Must().Pure())(1)
+			Expect(consumed).To(Equal([]int{1, 2}))
+		})
+	})
+})
@@ -17,7 +17,7 @@ type PureMustConsumer[T any] func(T)
 // Must returns a MustConsumer.
 func (c Consumer[T]) Must() MustConsumer[T] {
 	return func(ctx context.Context, t T) context.Context {
-		ctx, err := c(context.Background(), t)
+		ctx, err := c(ctx, t)
 		if err != nil {
 			panic(err)
 		}
@@ -52,6 +52,28 @@ func (c PureConsumer[T]) Must() PureMustConsumer[T] {
 	}
 }
 
+// Lift returns a Consumer that passes the context through and never returns error.
+func (c MustConsumer[T]) Lift() Consumer[T] {
+	return func(ctx context.Context, t T) (context.Context, error) {
+		return c(ctx, t), nil
+	}
+}
+
+// Lift returns a Consumer that passes the context through untouched.
+func (c PureConsumer[T]) Lift() Consumer[T] {
+	return func(ctx context.Context, t T) (context.Context, error) {
+		return ctx, c(t)
+	}
+}
+
+// Lift returns a Consumer that passes the context through untouched and never returns error.
+func (c PureMustConsumer[T]) Lift() Consumer[T] {
+	return func(ctx context.Context, t T) (context.Context, error) {
+		c(t)
+		return ctx, nil
+	}
+}
+
 // Then returns a composed Consumer that performs, in sequence, this operation followed by the after operation.
 func (c Consumer[T]) Then(after Consumer[T]) Consumer[T] {
 	return func(ctx context.Context, t T) (context.Context, error) {
@@ -139,6 +161,28 @@ func (c PureBiConsumer[T, U]) Must() PureMustBiConsumer[T, U] {
 	}
 }
 
+// Lift returns a BiConsumer that passes the context through and never returns error.
+func (c MustBiConsumer[T, U]) Lift() BiConsumer[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, error) {
+		return c(ctx, t, u), nil
+	}
+}
+
+// Lift returns a BiConsumer that passes the context through untouched.
+func (c PureBiConsumer[T, U]) Lift() BiConsumer[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, error) {
+		return ctx, c(t, u)
+	}
+}
+
+// Lift returns a BiConsumer that passes the context through untouched and never returns error.
+func (c PureMustBiConsumer[T, U]) Lift() BiConsumer[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, error) {
+		c(t, u)
+		return ctx, nil
+	}
+}
+
 // Then returns a composed BiConsumer that performs, in sequence, this operation followed by the after operation.
 func (c BiConsumer[T, U]) Then(after BiConsumer[T, U]) BiConsumer[T, U] {
 	return func(ctx context.Context, t T, u U) (context.Context, error) {
@@ -0,0 +1,47 @@
+package funk
+
+import "context"
+
+// Contramap returns a Consumer that applies f to its argument and performs c on the result, the context returned by
+// f is passed to c.
+func Contramap[U, T any](f Func[U, T], c Consumer[T]) Consumer[U] {
+	return func(ctx context.Context, u U) (context.Context, error) {
+		ctx, t, err := f(ctx, u)
+		if err != nil {
+			return ctx, err
+		}
+		return c(ctx, t)
+	}
+}
+
+// Tee returns a Consumer that performs all consumers in sequence on the same argument, threading the context through
+// each consumer. It stops on the first error.
+func Tee[T any](cs ...Consumer[T]) Consumer[T] {
+	return func(ctx context.Context, t T) (context.Context, error) {
+		for _, c := range cs {
+			var err error
+			if ctx, err = c(ctx, t); err != nil {
+				return ctx, err
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// TeeAll returns a Consumer that performs all consumers in sequence on the same argument. Unlike Tee, it performs all
+// consumers regardless of errors, it's the same as Collecting.
+func TeeAll[T any](cs ...Consumer[T]) Consumer[T] {
+	return Collecting(cs...)
+}
+
+// Both returns a BiConsumer that performs first on the first argument and then second on the second argument,
+// threading the context through both consumers. It doesn't perform second if first returns error.
+func Both[T, U any](first Consumer[T], second Consumer[U]) BiConsumer[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, error) {
+		ctx, err := first(ctx, t)
+		if err != nil {
+			return ctx, err
+		}
+		return second(ctx, u)
+	}
+}
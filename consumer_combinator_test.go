@@ -0,0 +1,84 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Consumer combinators", func() {
+	var consumed []string
+	record := func(prefix string, err error) funk.Consumer[string] {
+		return func(ctx context.Context, s string) (context.Context, error) {
+			consumed = append(consumed, prefix+s)
+			return incCtxValue(ctx), err
+		}
+	}
+	BeforeEach(func() {
+		consumed = nil
+	})
+
+	Describe("Contramap", func() {
+		It("should adapt the input type", func() {
+			c := funk.Contramap(funk.Func[int, string](func(ctx context.Context, i int) (context.Context, string, error) {
+				return incCtxValue(ctx), strconv.Itoa(i), nil
+			}), record("", nil))
+			ctx, err := c(context.Background(), 1)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(consumed).To(Equal([]string{"1"}))
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
+		It("should not consume if the function returns error", func() {
+			c := funk.Contramap(funk.Func[int, string](func(ctx context.Context, i int) (context.Context, string, error) {
+				return ctx, "", errors.New("")
+			}), record("", nil))
+			_, err := c(context.Background(), 1)
+			Expect(err).To(HaveOccurred())
+			Expect(consumed).To(BeEmpty())
+		})
+	})
+
+	Describe("Tee", func() {
+		It("should fan out to all consumers", func() {
+			ctx, err := funk.Tee(record("a", nil), record("b", nil))(context.Background(), "1")
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(consumed).To(Equal([]string{"a1", "b1"}))
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
+		It("should fail fast", func() {
+			_, err := funk.Tee(record("a", errors.New("a")), record("b", nil))(context.Background(), "1")
+			Expect(err).To(MatchError("a"))
+			Expect(consumed).To(Equal([]string{"a1"}))
+		})
+		It("should collect all errors", func() {
+			errA, errC := errors.New("a"), errors.New("c")
+			ctx, err := funk.TeeAll(record("a", errA), record("b", nil), record("c", errC))(context.Background(), "1")
+			Expect(err).To(MatchError(errA))
+			Expect(err).To(MatchError(errC))
+			Expect(consumed).To(Equal([]string{"a1", "b1", "c1"}))
+			Expect(getCtxValue(ctx)).To(Equal(1))
+			var se *funk.StageError
+			Expect(errors.As(err, &se)).To(BeTrue())
+			Expect(se.Name).To(Equal("0"))
+		})
+	})
+
+	Describe("Both", func() {
+		It("should consume both arguments", func() {
+			ctx, err := funk.Both(record("a", nil), record("b", nil))(context.Background(), "1", "2")
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(consumed).To(Equal([]string{"a1", "b2"}))
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
+		It("should not consume the second argument if the first consumer returns error", func() {
+			_, err := funk.Both(record("a", errors.New("")), record("b", nil))(context.Background(), "1", "2")
+			Expect(err).To(HaveOccurred())
+			Expect(consumed).To(Equal([]string{"a1"}))
+		})
+	})
+})
@@ -47,6 +47,14 @@ var _ = Describe("Consumer", func() {
 				Expect(ctx.Value("k")).To(Equal(1))
 			})
 		})
+
+		It("should pass the context to the original consumer", func() {
+			c = func(ctx context.Context, s string) (context.Context, error) {
+				return incCtxValue(ctx), nil
+			}
+			ctx := c.Must()(context.WithValue(context.Background(), "k", 1), "")
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
 	})
 
 	Describe("Converting to PureConsumer", func() {
@@ -773,3 +781,28 @@ var _ = Describe("PureMustBiConsumer", func() {
 		})
 	})
 })
+
+var _ = Describe("Lifting consumers", func() {
+	It("should lift MustConsumer to Consumer", func() {
+		c := funk.MustConsumer[int](func(ctx context.Context, i int) context.Context {
+			return incCtxValue(ctx)
+		}).Lift()
+		ctx, err := c(context.Background(), 1)
+		Expect(getCtxValue(ctx)).To(Equal(1))
+		Expect(err).To(Not(HaveOccurred()))
+	})
+	It("should lift PureMustConsumer to Consumer", func() {
+		var consumed int
+		c := funk.PureMustConsumer[int](func(i int) { consumed = i }).Lift()
+		ctx, err := c(incCtxValue(context.Background()), 1)
+		Expect(getCtxValue(ctx)).To(Equal(1))
+		Expect(consumed).To(Equal(1))
+		Expect(err).To(Not(HaveOccurred()))
+	})
+	It("should pass the context through MustConsumer converted from Consumer", func() {
+		c := funk.Consumer[int](func(ctx context.Context, i int) (context.Context, error) {
+			return incCtxValue(ctx), nil
+		}).Must()
+		Expect(getCtxValue(c(incCtxValue(context.Background()), 1))).To(Equal(2))
+	})
+})
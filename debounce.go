@@ -0,0 +1,254 @@
+package funk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrStopped is returned by the consumers of a stopped Debouncer or Throttler.
+var ErrStopped = errors.New("stopped")
+
+// Debouncer performs a Consumer only on the last argument of a burst, once no argument arrives for a quiet period.
+// It's safe for concurrent use, and delivers without holding its lock as Batcher does.
+type Debouncer[T any] struct {
+	quiet    time.Duration
+	consumer Consumer[T]
+	clock    Clock
+
+	mu      sync.Mutex
+	timer   Timer
+	gen     int
+	pending bool
+	value   T
+	ctx     context.Context
+	err     error
+	stopped bool
+}
+
+// Debounce returns a Debouncer that performs c after d elapses without new arguments.
+func Debounce[T any](d time.Duration, c Consumer[T]) *Debouncer[T] {
+	return &Debouncer[T]{quiet: d, consumer: c, clock: SystemClock}
+}
+
+// WithClock makes this debouncer use c instead of SystemClock, it must be called before use.
+func (d *Debouncer[T]) WithClock(c Clock) *Debouncer[T] {
+	d.clock = c
+	return d
+}
+
+// Consumer returns a Consumer that replaces the pending argument and restarts the quiet period. The argument is
+// delivered with its context without cancellation. The errors of deliveries are kept until the next call of Flush or
+// Stop, which returns them.
+func (d *Debouncer[T]) Consumer() Consumer[T] {
+	return func(ctx context.Context, t T) (context.Context, error) {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if d.stopped {
+			return ctx, ErrStopped
+		}
+		d.value, d.ctx, d.pending = t, context.WithoutCancel(ctx), true
+		d.cancel()
+		gen := d.gen
+		d.timer = d.clock.AfterFunc(d.quiet, func() {
+			d.mu.Lock()
+			if gen != d.gen {
+				d.mu.Unlock()
+				return
+			}
+			t, ctx, _ := d.take()
+			d.mu.Unlock()
+			_, err := d.consumer(ctx, t)
+			d.mu.Lock()
+			d.err = errors.Join(d.err, err)
+			d.mu.Unlock()
+		})
+		return ctx, nil
+	}
+}
+
+// Flush delivers the pending argument with ctx immediately, it does nothing if there is no pending argument. The error
+// of the delivery is joined with the errors of the deliveries since the last Flush or Stop.
+func (d *Debouncer[T]) Flush(ctx context.Context) (context.Context, error) {
+	d.mu.Lock()
+	prev := d.takeErr()
+	t, _, ok := d.take()
+	d.mu.Unlock()
+	if !ok {
+		return ctx, prev
+	}
+	ctx, err := d.consumer(ctx, t)
+	return ctx, errors.Join(prev, err)
+}
+
+// Stop discards the pending argument and makes the consumer return ErrStopped, it returns the errors of the deliveries
+// since the last Flush or Stop.
+func (d *Debouncer[T]) Stop() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stopped = true
+	d.cancel()
+	d.discard()
+	return d.takeErr()
+}
+
+// take takes the pending argument and its context, and stops the quiet period. It returns false if there is no
+// pending argument.
+func (d *Debouncer[T]) take() (T, context.Context, bool) {
+	t, ctx, ok := d.value, d.ctx, d.pending
+	d.cancel()
+	d.discard()
+	return t, ctx, ok
+}
+
+func (d *Debouncer[T]) cancel() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.gen++
+}
+
+func (d *Debouncer[T]) discard() {
+	var zero T
+	d.value, d.ctx, d.pending = zero, nil, false
+}
+
+func (d *Debouncer[T]) takeErr() error {
+	err := d.err
+	d.err = nil
+	return err
+}
+
+// Throttler performs a Consumer at most once per interval, on the first argument of the interval (leading edge),
+// the last argument of the interval (trailing edge), or both. It's safe for concurrent use, and delivers without
+// holding its lock as Batcher does.
+type Throttler[T any] struct {
+	interval time.Duration
+	consumer Consumer[T]
+	clock    Clock
+	leading  bool
+	trailing bool
+
+	mu      sync.Mutex
+	timer   Timer
+	gen     int
+	pending bool
+	value   T
+	ctx     context.Context
+	err     error
+	stopped bool
+}
+
+// Throttle returns a Throttler that performs c at most once per d on both edges.
+func Throttle[T any](d time.Duration, c Consumer[T]) *Throttler[T] {
+	return &Throttler[T]{interval: d, consumer: c, clock: SystemClock, leading: true, trailing: true}
+}
+
+// WithClock makes this throttler use c instead of SystemClock, it must be called before use.
+func (t *Throttler[T]) WithClock(c Clock) *Throttler[T] {
+	t.clock = c
+	return t
+}
+
+// WithEdges sets the edges on which this throttler delivers, it must be called before use.
+func (t *Throttler[T]) WithEdges(leading, trailing bool) *Throttler[T] {
+	t.leading, t.trailing = leading, trailing
+	return t
+}
+
+// Consumer returns a Consumer that starts an interval if none is running, and delivers the argument immediately with
+// its context on the leading edge. Otherwise, the argument replaces the pending argument of the trailing edge, which
+// is delivered with its context without cancellation when the interval ends, and starts the next interval.
+// The errors of trailing deliveries are kept until the next call of Flush or Stop, which returns them.
+func (t *Throttler[T]) Consumer() Consumer[T] {
+	return func(ctx context.Context, v T) (context.Context, error) {
+		t.mu.Lock()
+		if t.stopped {
+			t.mu.Unlock()
+			return ctx, ErrStopped
+		}
+		if t.timer == nil {
+			t.start()
+			if t.leading {
+				t.mu.Unlock()
+				return t.consumer(ctx, v)
+			}
+		}
+		if t.trailing {
+			t.value, t.ctx, t.pending = v, context.WithoutCancel(ctx), true
+		}
+		t.mu.Unlock()
+		return ctx, nil
+	}
+}
+
+// Flush delivers the pending argument of the trailing edge with ctx immediately, it does nothing if there is no
+// pending argument. The running interval is not affected. The error of the delivery is joined with the errors of the
+// trailing deliveries since the last Flush or Stop.
+func (t *Throttler[T]) Flush(ctx context.Context) (context.Context, error) {
+	t.mu.Lock()
+	prev := t.takeErr()
+	if !t.pending {
+		t.mu.Unlock()
+		return ctx, nil
+	}
+	v := t.value
+	t.discard()
+	t.mu.Unlock()
+	ctx, err := t.consumer(ctx, v)
+	return ctx, errors.Join(prev, err)
+}
+
+// Stop discards the pending argument and makes the consumer return ErrStopped, it returns the errors of the trailing
+// deliveries since the last Flush or Stop.
+func (t *Throttler[T]) Stop() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	t.gen++
+	t.discard()
+	return t.takeErr()
+}
+
+// start starts an interval, which delivers the pending argument when it ends.
+func (t *Throttler[T]) start() {
+	t.gen++
+	gen := t.gen
+	t.timer = t.clock.AfterFunc(t.interval, func() {
+		t.mu.Lock()
+		if gen != t.gen {
+			t.mu.Unlock()
+			return
+		}
+		t.timer = nil
+		if !t.pending {
+			t.mu.Unlock()
+			return
+		}
+		v, ctx := t.value, t.ctx
+		t.discard()
+		t.start()
+		t.mu.Unlock()
+		_, err := t.consumer(ctx, v)
+		t.mu.Lock()
+		t.err = errors.Join(t.err, err)
+		t.mu.Unlock()
+	})
+}
+
+func (t *Throttler[T]) discard() {
+	var zero T
+	t.value, t.ctx, t.pending = zero, nil, false
+}
+
+func (t *Throttler[T]) takeErr() error {
+	err := t.err
+	t.err = nil
+	return err
+}
@@ -0,0 +1,224 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Debounce and throttle", func() {
+	var clock *funk.ManualClock
+	var delivered []int
+	var fail error
+	record := funk.Consumer[int](func(ctx context.Context, i int) (context.Context, error) {
+		delivered = append(delivered, i)
+		return incCtxValue(ctx), fail
+	})
+	BeforeEach(func() {
+		clock = funk.NewManualClock(time.Unix(0, 0))
+		delivered, fail = nil, nil
+	})
+
+	Describe("Debounce", func() {
+		var d *funk.Debouncer[int]
+		var c funk.Consumer[int]
+		BeforeEach(func() {
+			d = funk.Debounce(10*time.Second, record).WithClock(clock)
+			c = d.Consumer()
+		})
+
+		It("should deliver the last value after the quiet period", func() {
+			_, _ = c(context.Background(), 1)
+			clock.Advance(5 * time.Second)
+			_, _ = c(context.Background(), 2)
+			clock.Advance(9 * time.Second)
+			Expect(delivered).To(BeEmpty())
+			clock.Advance(time.Second)
+			Expect(delivered).To(Equal([]int{2}))
+			clock.Advance(time.Minute)
+			Expect(delivered).To(Equal([]int{2}))
+		})
+		It("should deliver with an uncancelled context", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			_, _ = c(ctx, 1)
+			cancel()
+			var err error
+			d = funk.Debounce(time.Second, funk.Consumer[int](func(ctx context.Context, i int) (context.Context, error) {
+				err = ctx.Err()
+				return ctx, nil
+			})).WithClock(clock)
+			_, _ = d.Consumer()(ctx, 1)
+			clock.Advance(time.Second)
+			Expect(err).To(Not(HaveOccurred()))
+		})
+		It("should flush the pending value", func() {
+			_, _ = c(context.Background(), 1)
+			ctx, err := d.Flush(context.Background())
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(delivered).To(Equal([]int{1}))
+			Expect(getCtxValue(ctx)).To(Equal(1))
+			clock.Advance(time.Minute)
+			Expect(delivered).To(Equal([]int{1}))
+			_, _ = d.Flush(context.Background())
+			Expect(delivered).To(Equal([]int{1}))
+		})
+		It("should report errors of deliveries by Flush and Stop", func() {
+			failed := errors.New("")
+			fail = failed
+			_, _ = c(context.Background(), 1)
+			clock.Advance(10 * time.Second)
+			_, err := c(context.Background(), 2)
+			Expect(err).To(Not(HaveOccurred()))
+			fail = nil
+			_, err = d.Flush(context.Background())
+			Expect(err).To(MatchError(failed))
+			Expect(delivered).To(Equal([]int{1, 2}))
+
+			fail = errors.New("")
+			_, _ = c(context.Background(), 3)
+			clock.Advance(10 * time.Second)
+			Expect(d.Stop()).To(HaveOccurred())
+			Expect(d.Stop()).To(Succeed())
+		})
+		It("should discard the pending value when stopped", func() {
+			_, _ = c(context.Background(), 1)
+			Expect(d.Stop()).To(Succeed())
+			clock.Advance(time.Minute)
+			Expect(delivered).To(BeEmpty())
+			Expect(clock.Pending()).To(Equal(0))
+			_, err := c(context.Background(), 2)
+			Expect(err).To(MatchError(funk.ErrStopped))
+		})
+		It("should let deliveries call back into the debouncer", func() {
+			var again funk.Consumer[int]
+			d = funk.Debounce(time.Second, funk.Consumer[int](func(ctx context.Context, i int) (context.Context, error) {
+				delivered = append(delivered, i)
+				if i < 3 {
+					return again(ctx, i+1)
+				}
+				return d.Flush(ctx)
+			})).WithClock(clock)
+			again = d.Consumer()
+			_, _ = again(context.Background(), 1)
+			clock.Advance(time.Minute)
+			Expect(delivered).To(Equal([]int{1, 2, 3}))
+		})
+	})
+
+	Describe("Throttle", func() {
+		send := func(c funk.Consumer[int], vs ...int) {
+			for _, v := range vs {
+				_, _ = c(context.Background(), v)
+			}
+		}
+
+		It("should deliver on both edges at most once per interval", func() {
+			c := funk.Throttle(time.Second, record).WithClock(clock).Consumer()
+			send(c, 1, 2, 3)
+			Expect(delivered).To(Equal([]int{1}))
+			clock.Advance(time.Second)
+			Expect(delivered).To(Equal([]int{1, 3}))
+			send(c, 4)
+			Expect(delivered).To(Equal([]int{1, 3}))
+			clock.Advance(time.Second)
+			Expect(delivered).To(Equal([]int{1, 3, 4}))
+			clock.Advance(time.Second)
+			send(c, 5)
+			Expect(delivered).To(Equal([]int{1, 3, 4, 5}))
+		})
+		It("should deliver on the leading edge only", func() {
+			c := funk.Throttle(time.Second, record).WithClock(clock).WithEdges(true, false).Consumer()
+			send(c, 1, 2)
+			clock.Advance(time.Second)
+			send(c, 3, 4)
+			clock.Advance(time.Second)
+			Expect(delivered).To(Equal([]int{1, 3}))
+		})
+		It("should deliver on the trailing edge only", func() {
+			c := funk.Throttle(time.Second, record).WithClock(clock).WithEdges(false, true).Consumer()
+			send(c, 1, 2)
+			Expect(delivered).To(BeEmpty())
+			clock.Advance(time.Second)
+			Expect(delivered).To(Equal([]int{2}))
+		})
+		It("should report errors of trailing deliveries by Flush", func() {
+			t := funk.Throttle(time.Second, record).WithClock(clock)
+			c := t.Consumer()
+			send(c, 1, 2)
+			fail = errors.New("")
+			clock.Advance(time.Second)
+			Expect(delivered).To(Equal([]int{1, 2}))
+			_, err := c(context.Background(), 3)
+			Expect(err).To(Not(HaveOccurred()))
+			_, err = t.Flush(context.Background())
+			Expect(err).To(MatchError(fail))
+			Expect(t.Stop()).To(Succeed())
+		})
+		It("should return the context and error of leading deliveries", func() {
+			fail = errors.New("")
+			ctx, err := funk.Throttle(time.Second, record).WithClock(clock).Consumer()(context.Background(), 1)
+			Expect(err).To(MatchError(fail))
+			Expect(getCtxValue(ctx)).To(Equal(1))
+		})
+		It("should flush and stop", func() {
+			t := funk.Throttle(time.Second, record).WithClock(clock)
+			c := t.Consumer()
+			send(c, 1, 2)
+			_, err := t.Flush(context.Background())
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(delivered).To(Equal([]int{1, 2}))
+			send(c, 3)
+			Expect(t.Stop()).To(Succeed())
+			clock.Advance(time.Minute)
+			Expect(delivered).To(Equal([]int{1, 2}))
+			_, err = c(context.Background(), 4)
+			Expect(err).To(MatchError(funk.ErrStopped))
+		})
+		It("should let deliveries call back into the throttler", func() {
+			var t *funk.Throttler[int]
+			t = funk.Throttle(time.Second, funk.Consumer[int](func(ctx context.Context, i int) (context.Context, error) {
+				delivered = append(delivered, i)
+				return t.Flush(ctx)
+			})).WithClock(clock)
+			c := t.Consumer()
+			send(c, 1, 2)
+			clock.Advance(time.Second)
+			Expect(delivered).To(Equal([]int{1, 2}))
+		})
+		It("should work with the system clock", func() {
+			t := funk.Throttle(10*time.Millisecond, funk.Consumer[int](func(ctx context.Context, i int) (context.Context, error) {
+				return ctx, nil
+			}))
+			c := t.Consumer()
+			send(c, 1, 2)
+			Eventually(func() error {
+				_, err := t.Flush(context.Background())
+				return err
+			}).Should(Succeed())
+			Expect(t.Stop()).To(Succeed())
+		})
+	})
+
+	Describe("ManualClock", func() {
+		It("should fire timers in order of expiry", func() {
+			var fired []string
+			clock.AfterFunc(2*time.Second, func() { fired = append(fired, "b") })
+			clock.AfterFunc(time.Second, func() {
+				fired = append(fired, "a")
+				Expect(clock.Now()).To(Equal(time.Unix(1, 0)))
+				clock.AfterFunc(time.Second/2, func() { fired = append(fired, "c") })
+			})
+			stopped := clock.AfterFunc(time.Second, func() { fired = append(fired, "x") })
+			Expect(stopped.Stop()).To(BeTrue())
+			Expect(stopped.Stop()).To(BeFalse())
+			clock.Advance(3 * time.Second)
+			Expect(fired).To(Equal([]string{"a", "c", "b"}))
+			Expect(clock.Now()).To(Equal(time.Unix(3, 0)))
+		})
+	})
+})
@@ -0,0 +1,190 @@
+package funk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Explanation represents the trace of a predicate evaluation, it's a tree of nodes that mirrors how a composed
+// predicate is built by Named, And, Or, Not and the variadic combinators such as AllOf.
+// Predicates that are not named are reported as anonymous nodes. A name is only known once its predicate is evaluated,
+// so short-circuited operands are always reported as anonymous nodes, even if they are named; they can be told apart
+// by their positions among the children.
+type Explanation struct {
+	// Name is the name of the node, it's empty for anonymous predicates and short-circuited operands.
+	Name string
+	// Result is the boolean result of the node, it's meaningless if the node is short-circuited.
+	Result bool
+	// Err is the error returned by the node.
+	Err error
+	// ShortCircuited reports whether the node is skipped because the result is known before evaluating it.
+	ShortCircuited bool
+	// Children are the operands of the node in evaluation order.
+	Children []*Explanation
+
+	placeholder bool
+}
+
+// String renders the explanation as indented text, one node per line.
+func (e *Explanation) String() string {
+	var b strings.Builder
+	e.write(&b, 0)
+	return b.String()
+}
+
+func (e *Explanation) write(b *strings.Builder, depth int) {
+	b.WriteString(strings.Repeat("  ", depth))
+	name := e.Name
+	if name == "" {
+		name = "<anonymous>"
+	}
+	switch {
+	case e.ShortCircuited:
+		fmt.Fprintf(b, "%s: short-circuited\n", name)
+	case e.Err != nil:
+		fmt.Fprintf(b, "%s: %t (error: %v)\n", name, e.Result, e.Err)
+	default:
+		fmt.Fprintf(b, "%s: %t\n", name, e.Result)
+	}
+	for _, c := range e.Children {
+		c.write(b, depth+1)
+	}
+}
+
+// MarshalJSON encodes the explanation as a JSON object, errors are encoded as their messages.
+func (e *Explanation) MarshalJSON() ([]byte, error) {
+	var errMsg string
+	if e.Err != nil {
+		errMsg = e.Err.Error()
+	}
+	return json.Marshal(struct {
+		Name           string         `json:"name,omitempty"`
+		Result         bool           `json:"result"`
+		Error          string         `json:"error,omitempty"`
+		ShortCircuited bool           `json:"shortCircuited,omitempty"`
+		Children       []*Explanation `json:"children,omitempty"`
+	}{e.Name, e.Result, errMsg, e.ShortCircuited, e.Children})
+}
+
+type explainerKey struct{}
+
+// explainer records the explanation of a single evaluation, it's carried by the context.
+type explainer struct {
+	stack []*Explanation
+}
+
+func explainerFrom(ctx context.Context) *explainer {
+	e, _ := ctx.Value(explainerKey{}).(*explainer)
+	return e
+}
+
+func (e *explainer) top() *Explanation {
+	return e.stack[len(e.stack)-1]
+}
+
+func (e *explainer) push(node *Explanation) {
+	top := e.top()
+	top.Children = append(top.Children, node)
+	e.stack = append(e.stack, node)
+}
+
+func (e *explainer) pop() {
+	e.stack = e.stack[:len(e.stack)-1]
+}
+
+// explainNode evaluates a node with name. If the node is the whole operand of its parent, the operand is named after
+// it instead of adding a new child.
+func explainNode(ctx context.Context, name string, eval evaluation) (context.Context, bool, error) {
+	e := explainerFrom(ctx)
+	if e == nil {
+		return eval(ctx)
+	}
+	node := e.top()
+	claimed := node.placeholder
+	if claimed {
+		node.placeholder = false
+		node.Name = name
+	} else {
+		node = &Explanation{Name: name}
+		e.push(node)
+		defer e.pop()
+	}
+	ctx, v, err := eval(ctx)
+	node.Result, node.Err = v, err
+	return ctx, v, err
+}
+
+// explainOperand evaluates an operand of a composed predicate as an anonymous node, which may be named later by
+// explainNode.
+func explainOperand(ctx context.Context, eval evaluation) (context.Context, bool, error) {
+	e := explainerFrom(ctx)
+	if e == nil {
+		return eval(ctx)
+	}
+	node := &Explanation{placeholder: true}
+	e.push(node)
+	defer e.pop()
+	ctx, v, err := eval(ctx)
+	node.placeholder = false
+	node.Result, node.Err = v, err
+	return ctx, v, err
+}
+
+// explainSkipped records a short-circuited operand.
+func explainSkipped(ctx context.Context) {
+	if e := explainerFrom(ctx); e != nil {
+		top := e.top()
+		top.Children = append(top.Children, &Explanation{ShortCircuited: true})
+	}
+}
+
+func explain(ctx context.Context, eval evaluation) (context.Context, *Explanation) {
+	root := &Explanation{}
+	e := &explainer{stack: []*Explanation{root}}
+	ctx, _, _ = explainOperand(context.WithValue(ctx, explainerKey{}, e), eval)
+	if explainerFrom(ctx) != nil {
+		// Detach the finished explanation, so later evaluations with the returned context don't record into it.
+		ctx = context.WithValue(ctx, explainerKey{}, (*explainer)(nil))
+	}
+	return ctx, root.Children[0]
+}
+
+// Named returns a Predicate that is reported with name when explained.
+func (p Predicate[T]) Named(name string) Predicate[T] {
+	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		return explainNode(ctx, name, p.bind(t))
+	}
+}
+
+// Named returns a MustPredicate that is reported with name when explained.
+func (p MustPredicate[T]) Named(name string) MustPredicate[T] {
+	return p.Lift().Named(name).Must()
+}
+
+// Named returns a BiPredicate that is reported with name when explained.
+func (p BiPredicate[T, U]) Named(name string) BiPredicate[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, bool, error) {
+		return explainNode(ctx, name, p.bind(t, u))
+	}
+}
+
+// Named returns a MustBiPredicate that is reported with name when explained.
+func (p MustBiPredicate[T, U]) Named(name string) MustBiPredicate[T, U] {
+	return p.Lift().Named(name).Must()
+}
+
+// Explain evaluates this predicate and returns the trace of the evaluation, the result and error of the predicate are
+// those of the returned explanation.
+// Pure predicates don't carry context, so they are always explained as leaves.
+func (p Predicate[T]) Explain(ctx context.Context, t T) (context.Context, *Explanation) {
+	return explain(ctx, p.bind(t))
+}
+
+// Explain evaluates this predicate and returns the trace of the evaluation, the result and error of the predicate are
+// those of the returned explanation.
+// Pure predicates don't carry context, so they are always explained as leaves.
+func (p BiPredicate[T, U]) Explain(ctx context.Context, t T, u U) (context.Context, *Explanation) {
+	return explain(ctx, p.bind(t, u))
+}
@@ -0,0 +1,119 @@
+package funk_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Explaining predicates", func() {
+	type user struct {
+		age     int
+		country string
+		vip     bool
+	}
+	adult := funk.PureMustPredicate[user](func(u user) bool { return u.age >= 18 }).Lift().Named("adult")
+	european := funk.PureMustPredicate[user](func(u user) bool {
+		return u.country == "DE" || u.country == "FR"
+	}).Lift().Named("european")
+	vip := funk.PureMustPredicate[user](func(u user) bool { return u.vip }).Lift().Named("vip")
+	p := adult.And(european.Or(vip)).Named("eligible")
+
+	It("should report each node", func() {
+		_, e := p.Explain(context.Background(), user{age: 20, country: "US", vip: true})
+		Expect(e.Name).To(Equal("eligible"))
+		Expect(e.Result).To(BeTrue())
+		Expect(e.String()).To(Equal(`eligible: true
+  and: true
+    adult: true
+    or: true
+      european: false
+      vip: true
+`))
+	})
+
+	It("should report short-circuited nodes", func() {
+		_, e := p.Explain(context.Background(), user{age: 10})
+		Expect(e.Result).To(BeFalse())
+		Expect(e.String()).To(Equal(`eligible: false
+  and: false
+    adult: false
+    <anonymous>: short-circuited
+`))
+	})
+
+	It("should report errors and anonymous predicates", func() {
+		failing := funk.Predicate[user](func(ctx context.Context, u user) (context.Context, bool, error) {
+			return ctx, false, errors.New("boom")
+		})
+		_, e := funk.AllOf(adult, failing, vip).Not().Explain(context.Background(), user{age: 20})
+		Expect(e.Err).To(HaveOccurred())
+		Expect(e.String()).To(Equal(`not: true (error: boom)
+  allOf: false (error: boom)
+    adult: true
+    <anonymous>: false (error: boom)
+    <anonymous>: short-circuited
+`))
+	})
+
+	It("should propagate context", func() {
+		inc := funk.Predicate[user](func(ctx context.Context, u user) (context.Context, bool, error) {
+			return incCtxValue(ctx), true, nil
+		})
+		ctx, e := inc.And(inc.Named("inc")).Explain(context.Background(), user{})
+		Expect(getCtxValue(ctx)).To(Equal(2))
+		Expect(e.Children[1].Name).To(Equal("inc"))
+	})
+
+	It("should be encoded as JSON", func() {
+		_, e := adult.And(vip).Explain(context.Background(), user{age: 1})
+		b, err := json.Marshal(e)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(b).To(MatchJSON(`{"name":"and","result":false,"children":[
+			{"name":"adult","result":false},
+			{"result":false,"shortCircuited":true}
+		]}`))
+	})
+
+	It("should explain BiPredicate", func() {
+		lt := funk.PureMustBiPredicate[int, int](func(a, b int) bool { return a < b }).Lift().Named("lt")
+		_, e := lt.Not().Explain(context.Background(), 1, 2)
+		Expect(e.String()).To(Equal("not: false\n  lt: true\n"))
+	})
+
+	It("should explain MustPredicate combinators", func() {
+		must := adult.Must().And(vip.Must().Not())
+		_, e := must.Lift().Explain(context.Background(), user{age: 20})
+		Expect(e.String()).To(Equal("and: true\n  adult: true\n  not: true\n    vip: false\n"))
+	})
+
+	It("should not affect evaluation without explaining", func() {
+		_, v, err := p(context.Background(), user{age: 20, country: "DE"})
+		Expect(v).To(BeTrue())
+		Expect(err).To(Not(HaveOccurred()))
+	})
+
+	It("should not record evaluations with the returned context", func() {
+		ctx, e := p.Explain(context.Background(), user{age: 10})
+		before := e.String()
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, v, err := p(ctx, user{age: 20, country: "DE"})
+				Expect(v).To(BeTrue())
+				Expect(err).To(Not(HaveOccurred()))
+			}()
+		}
+		wg.Wait()
+		Expect(e.String()).To(Equal(before))
+	})
+})
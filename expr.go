@@ -0,0 +1,275 @@
+package funk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Operators of Expr.
+const (
+	OpAnd  = "and"
+	OpOr   = "or"
+	OpNot  = "not"
+	OpLeaf = "leaf"
+)
+
+// Expr represents a serializable expression tree of predicates, it's compiled to Predicate by a PredicateRegistry.
+// Leaves refer to predicate factories by name and carry the parameters to create the predicates.
+type Expr struct {
+	// Op is one of OpAnd, OpOr, OpNot and OpLeaf.
+	Op string `json:"op"`
+	// Operands are the operands of OpAnd, OpOr and OpNot, OpNot has exactly one operand.
+	Operands []*Expr `json:"operands,omitempty"`
+	// Name is the name of the predicate factory of OpLeaf.
+	Name string `json:"name,omitempty"`
+	// Params are the parameters passed to the predicate factory of OpLeaf.
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// ExprAnd returns an Expr that represents a short-circuiting logical AND of all operands.
+func ExprAnd(operands ...*Expr) *Expr {
+	return &Expr{Op: OpAnd, Operands: operands}
+}
+
+// ExprOr returns an Expr that represents a short-circuiting logical OR of all operands.
+func ExprOr(operands ...*Expr) *Expr {
+	return &Expr{Op: OpOr, Operands: operands}
+}
+
+// ExprNot returns an Expr that represents the logical negation of operand.
+func ExprNot(operand *Expr) *Expr {
+	return &Expr{Op: OpNot, Operands: []*Expr{operand}}
+}
+
+// ExprLeaf returns an Expr that refers to the predicate factory named name with params.
+// It panics if params can't be encoded as JSON.
+func ExprLeaf(name string, params any) *Expr {
+	e := &Expr{Op: OpLeaf, Name: name}
+	if params != nil {
+		b, err := json.Marshal(params)
+		if err != nil {
+			panic(err)
+		}
+		e.Params = b
+	}
+	return e
+}
+
+// LeafCompare is the name of the comparison leaf, whose parameters are Comparison.
+// It's compiled by the factory returned by PredicateParser.CompareFactory and translated by SQLTranslator.
+const LeafCompare = "compare"
+
+// Comparison represents the parameters of a comparison leaf, which compares a field with a value.
+type Comparison struct {
+	// Field is the name of the field.
+	Field string `json:"field"`
+	// Op is one of ==, !=, <, <=, >, >= and in.
+	Op string `json:"op"`
+	// Value is a number, string or bool, or a list of them if Op is in.
+	Value any `json:"value"`
+}
+
+// ExprCompare returns a comparison leaf that compares field with value by op.
+func ExprCompare(field, op string, value any) *Expr {
+	return ExprLeaf(LeafCompare, Comparison{Field: field, Op: op, Value: value})
+}
+
+// ExprIn returns a comparison leaf that tests if field is equal to any of values.
+func ExprIn(field string, values ...any) *Expr {
+	if values == nil {
+		values = []any{}
+	}
+	return ExprCompare(field, "in", values)
+}
+
+// decodeComparison decodes the parameters of a comparison leaf, numbers in values are decoded as json.Number.
+func decodeComparison(params json.RawMessage) (Comparison, []any, error) {
+	var c Comparison
+	dec := json.NewDecoder(bytes.NewReader(params))
+	dec.UseNumber()
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return c, nil, err
+	}
+	if c.Field == "" {
+		return c, nil, errors.New("comparison requires a field")
+	}
+	var values []any
+	switch c.Op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		values = []any{c.Value}
+	case "in":
+		list, ok := c.Value.([]any)
+		if !ok {
+			return c, nil, fmt.Errorf("operator %q requires a list of values", c.Op)
+		}
+		values = list
+	default:
+		return c, nil, fmt.Errorf("unknown comparison operator %q", c.Op)
+	}
+	for _, v := range values {
+		switch v.(type) {
+		case json.Number, string, bool:
+		default:
+			return c, nil, fmt.Errorf("unsupported value %v", v)
+		}
+	}
+	return c, values, nil
+}
+
+// ExprError represents an error of a node in an Expr.
+type ExprError struct {
+	// Path is the path of the node, such as $.operands[1].operands[0].
+	Path string
+	Err  error
+}
+
+func (e *ExprError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e *ExprError) Unwrap() error {
+	return e.Err
+}
+
+// Validate reports an *ExprError for the first malformed node of the expression tree.
+func (e *Expr) Validate() error {
+	return e.validate("$")
+}
+
+func (e *Expr) validate(path string) error {
+	if e == nil {
+		return &ExprError{Path: path, Err: errors.New("missing expression")}
+	}
+	switch e.Op {
+	case OpAnd, OpOr:
+	case OpNot:
+		if len(e.Operands) != 1 {
+			return &ExprError{Path: path, Err: fmt.Errorf("%q requires exactly one operand, got %d", e.Op, len(e.Operands))}
+		}
+	case OpLeaf:
+		if e.Name == "" {
+			return &ExprError{Path: path, Err: errors.New("leaf requires a name")}
+		}
+		if len(e.Operands) != 0 {
+			return &ExprError{Path: path, Err: errors.New("leaf can't have operands")}
+		}
+		return nil
+	default:
+		return &ExprError{Path: path, Err: fmt.Errorf("unknown operator %q", e.Op)}
+	}
+	for i, o := range e.Operands {
+		if err := o.validate(fmt.Sprintf("%s.operands[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes and validates the expression tree, errors of malformed nodes are reported as *ExprError.
+func (e *Expr) UnmarshalJSON(data []byte) error {
+	if err := e.decode(data, "$"); err != nil {
+		return err
+	}
+	return e.Validate()
+}
+
+func (e *Expr) decode(data []byte, path string) error {
+	var raw struct {
+		Op       string            `json:"op"`
+		Operands []json.RawMessage `json:"operands"`
+		Name     string            `json:"name"`
+		Params   json.RawMessage   `json:"params"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&raw); err != nil {
+		return &ExprError{Path: path, Err: err}
+	}
+	*e = Expr{Op: raw.Op, Name: raw.Name, Params: raw.Params}
+	for i, o := range raw.Operands {
+		operand := &Expr{}
+		if err := operand.decode(o, fmt.Sprintf("%s.operands[%d]", path, i)); err != nil {
+			return err
+		}
+		e.Operands = append(e.Operands, operand)
+	}
+	return nil
+}
+
+// PredicateFactory creates a Predicate from the parameters of a leaf.
+type PredicateFactory[T any] func(params json.RawMessage) (Predicate[T], error)
+
+// ParamsFactory returns a PredicateFactory that decodes the parameters of a leaf as P before creating the predicate
+// by f. Missing parameters are decoded as the zero value of P.
+func ParamsFactory[T, P any](f func(P) (Predicate[T], error)) PredicateFactory[T] {
+	return func(params json.RawMessage) (Predicate[T], error) {
+		var p P
+		if len(params) != 0 {
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+		}
+		return f(p)
+	}
+}
+
+// PredicateRegistry represents a registry of named predicate factories that compiles Expr to Predicate.
+type PredicateRegistry[T any] struct {
+	factories map[string]PredicateFactory[T]
+}
+
+// NewPredicateRegistry returns an empty PredicateRegistry.
+func NewPredicateRegistry[T any]() *PredicateRegistry[T] {
+	return &PredicateRegistry[T]{factories: map[string]PredicateFactory[T]{}}
+}
+
+// Register registers factory with name, it panics if name is already registered.
+func (r *PredicateRegistry[T]) Register(name string, factory PredicateFactory[T]) *PredicateRegistry[T] {
+	if _, ok := r.factories[name]; ok {
+		panic(fmt.Sprintf("funk: predicate factory %q is already registered", name))
+	}
+	r.factories[name] = factory
+	return r
+}
+
+// Compile compiles e to a Predicate, leaves are named after their factories when explained.
+// Errors of malformed nodes, unknown leaves and failed factories are reported as *ExprError.
+func (r *PredicateRegistry[T]) Compile(e *Expr) (Predicate[T], error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return r.compile(e, "$")
+}
+
+func (r *PredicateRegistry[T]) compile(e *Expr, path string) (Predicate[T], error) {
+	if e.Op == OpLeaf {
+		factory, ok := r.factories[e.Name]
+		if !ok {
+			return nil, &ExprError{Path: path, Err: fmt.Errorf("unknown leaf %q", e.Name)}
+		}
+		p, err := factory(e.Params)
+		if err != nil {
+			return nil, &ExprError{Path: path, Err: fmt.Errorf("leaf %q: %w", e.Name, err)}
+		}
+		return p.Named(e.Name), nil
+	}
+	operands := make([]Predicate[T], len(e.Operands))
+	for i, o := range e.Operands {
+		p, err := r.compile(o, fmt.Sprintf("%s.operands[%d]", path, i))
+		if err != nil {
+			return nil, err
+		}
+		operands[i] = p
+	}
+	switch e.Op {
+	case OpAnd:
+		return AllOf(operands...), nil
+	case OpOr:
+		return AnyOf(operands...), nil
+	default:
+		return operands[0].Not(), nil
+	}
+}
@@ -0,0 +1,113 @@
+package funk_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Expr", func() {
+	var r *funk.PredicateRegistry[int]
+	BeforeEach(func() {
+		r = funk.NewPredicateRegistry[int]().
+			Register("gt", funk.ParamsFactory(func(v int) (funk.Predicate[int], error) {
+				return funk.Gt(v).Lift(), nil
+			})).
+			Register("between", funk.ParamsFactory(func(p struct{ Lo, Hi int }) (funk.Predicate[int], error) {
+				if p.Lo > p.Hi {
+					return nil, errors.New("lo is greater than hi")
+				}
+				return funk.Between(p.Lo, p.Hi).Lift(), nil
+			})).
+			Register("even", func(json.RawMessage) (funk.Predicate[int], error) {
+				return funk.PureMustPredicate[int](func(i int) bool { return i%2 == 0 }).Lift(), nil
+			})
+	})
+
+	expr := funk.ExprOr(
+		funk.ExprAnd(funk.ExprLeaf("gt", 10), funk.ExprNot(funk.ExprLeaf("even", nil))),
+		funk.ExprLeaf("between", map[string]int{"Lo": 0, "Hi": 3}),
+	)
+	evaluate := func(p funk.Predicate[int], i int) bool {
+		_, v, err := p(context.Background(), i)
+		Expect(err).To(Not(HaveOccurred()))
+		return v
+	}
+
+	It("should compile to predicate", func() {
+		p, err := r.Compile(expr)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(evaluate(p, 11)).To(BeTrue())
+		Expect(evaluate(p, 12)).To(BeFalse())
+		Expect(evaluate(p, 2)).To(BeTrue())
+		Expect(evaluate(p, 5)).To(BeFalse())
+	})
+
+	It("should behave identically after round-tripping", func() {
+		b, err := json.Marshal(expr)
+		Expect(err).To(Not(HaveOccurred()))
+		var decoded funk.Expr
+		Expect(json.Unmarshal(b, &decoded)).To(Succeed())
+		Expect(&decoded).To(Equal(expr))
+
+		p1, _ := r.Compile(expr)
+		p2, err := r.Compile(&decoded)
+		Expect(err).To(Not(HaveOccurred()))
+		for i := -5; i < 20; i++ {
+			Expect(evaluate(p2, i)).To(Equal(evaluate(p1, i)))
+		}
+	})
+
+	It("should name leaves after their factories", func() {
+		p, _ := r.Compile(expr)
+		_, e := p.Explain(context.Background(), 2)
+		Expect(e.String()).To(Equal(`anyOf: true
+  allOf: false
+    gt: false
+    <anonymous>: short-circuited
+  between: true
+`))
+	})
+
+	DescribeTable("reporting the bad node",
+		func(data string, path string) {
+			var e funk.Expr
+			err := json.Unmarshal([]byte(data), &e)
+			var exprErr *funk.ExprError
+			Expect(errors.As(err, &exprErr)).To(BeTrue())
+			Expect(exprErr.Path).To(Equal(path))
+		},
+		Entry("unknown operator", `{"op":"and","operands":[{"op":"xor"}]}`, "$.operands[0]"),
+		Entry("not with two operands",
+			`{"op":"or","operands":[{"op":"leaf","name":"a"},{"op":"not","operands":[{"op":"leaf","name":"a"},{"op":"leaf","name":"b"}]}]}`,
+			"$.operands[1]"),
+		Entry("leaf without name", `{"op":"not","operands":[{"op":"leaf"}]}`, "$.operands[0]"),
+		Entry("unknown field", `{"op":"and","operands":[{"op":"leaf","name":"a","param":1}]}`, "$.operands[0]"),
+	)
+
+	When("Compiling malformed expression", func() {
+		It("should report unknown leaves", func() {
+			_, err := r.Compile(funk.ExprAnd(funk.ExprLeaf("gt", 1), funk.ExprLeaf("odd", nil)))
+			Expect(err).To(MatchError("$.operands[1]: unknown leaf \"odd\""))
+		})
+		It("should report failed factories", func() {
+			_, err := r.Compile(funk.ExprNot(funk.ExprLeaf("between", map[string]int{"Lo": 3, "Hi": 0})))
+			Expect(err).To(MatchError("$.operands[0]: leaf \"between\": lo is greater than hi"))
+		})
+		It("should report invalid parameters", func() {
+			_, err := r.Compile(funk.ExprLeaf("gt", "1"))
+			var exprErr *funk.ExprError
+			Expect(errors.As(err, &exprErr)).To(BeTrue())
+			Expect(exprErr.Path).To(Equal("$"))
+		})
+	})
+
+	It("should panic when registering a name twice", func() {
+		Expect(func() { r.Register("gt", nil) }).To(Panic())
+	})
+})
@@ -0,0 +1,353 @@
+package funk
+
+import (
+	"context"
+	"errors"
+)
+
+// attempt represents a call with its arguments bound.
+type attempt[R any] func(context.Context) (context.Context, R, error)
+
+func fallback[R any](
+	ctx context.Context, primary, secondary attempt[R], when Predicate[error],
+) (context.Context, R, error) {
+	ctx, r, err := primary(ctx)
+	if err == nil {
+		return ctx, r, nil
+	}
+	if when != nil {
+		ctx2, ok, werr := when(ctx, err)
+		ctx = ctx2
+		if werr != nil {
+			return ctx, r, errors.Join(err, werr)
+		}
+		if !ok {
+			return ctx, r, err
+		}
+	}
+	return secondary(ctx)
+}
+
+func recoverWith[R any](ctx context.Context, primary attempt[R], h Func[error, R]) (context.Context, R, error) {
+	ctx, r, err := primary(ctx)
+	if err == nil {
+		return ctx, r, nil
+	}
+	return h(ctx, err)
+}
+
+func mapError[R any](ctx context.Context, primary attempt[R], m Func[error, error]) (context.Context, R, error) {
+	ctx, r, err := primary(ctx)
+	if err == nil {
+		return ctx, r, nil
+	}
+	ctx, mapped, merr := m(ctx, err)
+	if merr != nil {
+		return ctx, r, errors.Join(err, merr)
+	}
+	return ctx, r, mapped
+}
+
+func orElseGet[R any](s Supplier[R]) Func[error, R] {
+	return func(ctx context.Context, _ error) (context.Context, R, error) {
+		return s(ctx)
+	}
+}
+
+func consumerAttempt(c func(context.Context) (context.Context, error)) attempt[struct{}] {
+	return func(ctx context.Context) (context.Context, struct{}, error) {
+		ctx, err := c(ctx)
+		return ctx, struct{}{}, err
+	}
+}
+
+func consumerRecovery(h Consumer[error]) Func[error, struct{}] {
+	return func(ctx context.Context, err error) (context.Context, struct{}, error) {
+		ctx, err = h(ctx, err)
+		return ctx, struct{}{}, err
+	}
+}
+
+// Fallback returns a Func that applies secondary to the arguments if this function returns an error that when
+// evaluates to true, or any error if when is nil. The context returned by this function is passed to secondary.
+func (f Func[T, R]) Fallback(secondary Func[T, R], when Predicate[error]) Func[T, R] {
+	return func(ctx context.Context, t T) (context.Context, R, error) {
+		return fallback(ctx, func(ctx context.Context) (context.Context, R, error) {
+			return f(ctx, t)
+		}, func(ctx context.Context) (context.Context, R, error) {
+			return secondary(ctx, t)
+		}, when)
+	}
+}
+
+// Recover returns a Func that substitutes the result of h for the error of this function.
+func (f Func[T, R]) Recover(h Func[error, R]) Func[T, R] {
+	return func(ctx context.Context, t T) (context.Context, R, error) {
+		return recoverWith(ctx, func(ctx context.Context) (context.Context, R, error) {
+			return f(ctx, t)
+		}, h)
+	}
+}
+
+// MapError returns a Func that replaces the error of this function with the result of m, the error is removed if m
+// returns nil. The error of m is joined with the original error.
+func (f Func[T, R]) MapError(m Func[error, error]) Func[T, R] {
+	return func(ctx context.Context, t T) (context.Context, R, error) {
+		return mapError(ctx, func(ctx context.Context) (context.Context, R, error) {
+			return f(ctx, t)
+		}, m)
+	}
+}
+
+// OrElseGet returns a Func that substitutes the result of other for the error of this function.
+func (f Func[T, R]) OrElseGet(other Supplier[R]) Func[T, R] {
+	return f.Recover(orElseGet(other))
+}
+
+// Fallback returns a Unary that applies secondary to the arguments if this function returns an error that when
+// evaluates to true, or any error if when is nil. The context returned by this function is passed to secondary.
+func (u Unary[T]) Fallback(secondary Unary[T], when Predicate[error]) Unary[T] {
+	return func(ctx context.Context, t T) (context.Context, T, error) {
+		return fallback(ctx, func(ctx context.Context) (context.Context, T, error) {
+			return u(ctx, t)
+		}, func(ctx context.Context) (context.Context, T, error) {
+			return secondary(ctx, t)
+		}, when)
+	}
+}
+
+// Recover returns a Unary that substitutes the result of h for the error of this function.
+func (u Unary[T]) Recover(h Func[error, T]) Unary[T] {
+	return func(ctx context.Context, t T) (context.Context, T, error) {
+		return recoverWith(ctx, func(ctx context.Context) (context.Context, T, error) {
+			return u(ctx, t)
+		}, h)
+	}
+}
+
+// MapError returns a Unary that replaces the error of this function with the result of m, the error is removed if m
+// returns nil. The error of m is joined with the original error.
+func (u Unary[T]) MapError(m Func[error, error]) Unary[T] {
+	return func(ctx context.Context, t T) (context.Context, T, error) {
+		return mapError(ctx, func(ctx context.Context) (context.Context, T, error) {
+			return u(ctx, t)
+		}, m)
+	}
+}
+
+// OrElseGet returns a Unary that substitutes the result of other for the error of this function.
+func (u Unary[T]) OrElseGet(other Supplier[T]) Unary[T] {
+	return u.Recover(orElseGet(other))
+}
+
+// Fallback returns a BiFunc that applies secondary to the arguments if this function returns an error that when
+// evaluates to true, or any error if when is nil. The context returned by this function is passed to secondary.
+func (f BiFunc[T, U, R]) Fallback(secondary BiFunc[T, U, R], when Predicate[error]) BiFunc[T, U, R] {
+	return func(ctx context.Context, t T, u U) (context.Context, R, error) {
+		return fallback(ctx, func(ctx context.Context) (context.Context, R, error) {
+			return f(ctx, t, u)
+		}, func(ctx context.Context) (context.Context, R, error) {
+			return secondary(ctx, t, u)
+		}, when)
+	}
+}
+
+// Recover returns a BiFunc that substitutes the result of h for the error of this function.
+func (f BiFunc[T, U, R]) Recover(h Func[error, R]) BiFunc[T, U, R] {
+	return func(ctx context.Context, t T, u U) (context.Context, R, error) {
+		return recoverWith(ctx, func(ctx context.Context) (context.Context, R, error) {
+			return f(ctx, t, u)
+		}, h)
+	}
+}
+
+// MapError returns a BiFunc that replaces the error of this function with the result of m, the error is removed if m
+// returns nil. The error of m is joined with the original error.
+func (f BiFunc[T, U, R]) MapError(m Func[error, error]) BiFunc[T, U, R] {
+	return func(ctx context.Context, t T, u U) (context.Context, R, error) {
+		return mapError(ctx, func(ctx context.Context) (context.Context, R, error) {
+			return f(ctx, t, u)
+		}, m)
+	}
+}
+
+// OrElseGet returns a BiFunc that substitutes the result of other for the error of this function.
+func (f BiFunc[T, U, R]) OrElseGet(other Supplier[R]) BiFunc[T, U, R] {
+	return f.Recover(orElseGet(other))
+}
+
+// Fallback returns a Supplier that gets secondary if this supplier returns an error that when evaluates to true,
+// or any error if when is nil. The context returned by this supplier is passed to secondary.
+func (s Supplier[T]) Fallback(secondary Supplier[T], when Predicate[error]) Supplier[T] {
+	return func(ctx context.Context) (context.Context, T, error) {
+		return fallback(ctx, func(ctx context.Context) (context.Context, T, error) {
+			return s(ctx)
+		}, func(ctx context.Context) (context.Context, T, error) {
+			return secondary(ctx)
+		}, when)
+	}
+}
+
+// Recover returns a Supplier that substitutes the result of h for the error of this supplier.
+func (s Supplier[T]) Recover(h Func[error, T]) Supplier[T] {
+	return func(ctx context.Context) (context.Context, T, error) {
+		return recoverWith(ctx, func(ctx context.Context) (context.Context, T, error) {
+			return s(ctx)
+		}, h)
+	}
+}
+
+// MapError returns a Supplier that replaces the error of this supplier with the result of m, the error is removed if m
+// returns nil. The error of m is joined with the original error.
+func (s Supplier[T]) MapError(m Func[error, error]) Supplier[T] {
+	return func(ctx context.Context) (context.Context, T, error) {
+		return mapError(ctx, func(ctx context.Context) (context.Context, T, error) {
+			return s(ctx)
+		}, m)
+	}
+}
+
+// OrElseGet returns a Supplier that substitutes the result of other for the error of this supplier.
+func (s Supplier[T]) OrElseGet(other Supplier[T]) Supplier[T] {
+	return s.Recover(orElseGet(other))
+}
+
+// Fallback returns a Predicate that evaluates secondary on the arguments if this predicate returns an error that when
+// evaluates to true, or any error if when is nil. The context returned by this predicate is passed to secondary.
+func (p Predicate[T]) Fallback(secondary Predicate[T], when Predicate[error]) Predicate[T] {
+	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		return fallback(ctx, func(ctx context.Context) (context.Context, bool, error) {
+			return p(ctx, t)
+		}, func(ctx context.Context) (context.Context, bool, error) {
+			return secondary(ctx, t)
+		}, when)
+	}
+}
+
+// Recover returns a Predicate that substitutes the result of h for the error of this predicate.
+func (p Predicate[T]) Recover(h Func[error, bool]) Predicate[T] {
+	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		return recoverWith(ctx, func(ctx context.Context) (context.Context, bool, error) {
+			return p(ctx, t)
+		}, h)
+	}
+}
+
+// MapError returns a Predicate that replaces the error of this predicate with the result of m, the error is removed if
+// m returns nil. The error of m is joined with the original error.
+func (p Predicate[T]) MapError(m Func[error, error]) Predicate[T] {
+	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		return mapError(ctx, func(ctx context.Context) (context.Context, bool, error) {
+			return p(ctx, t)
+		}, m)
+	}
+}
+
+// OrElseGet returns a Predicate that substitutes the result of other for the error of this predicate.
+func (p Predicate[T]) OrElseGet(other Supplier[bool]) Predicate[T] {
+	return p.Recover(orElseGet(other))
+}
+
+// Fallback returns a BiPredicate that evaluates secondary on the arguments if this predicate returns an error that when
+// evaluates to true, or any error if when is nil. The context returned by this predicate is passed to secondary.
+func (p BiPredicate[T, U]) Fallback(secondary BiPredicate[T, U], when Predicate[error]) BiPredicate[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, bool, error) {
+		return fallback(ctx, func(ctx context.Context) (context.Context, bool, error) {
+			return p(ctx, t, u)
+		}, func(ctx context.Context) (context.Context, bool, error) {
+			return secondary(ctx, t, u)
+		}, when)
+	}
+}
+
+// Recover returns a BiPredicate that substitutes the result of h for the error of this predicate.
+func (p BiPredicate[T, U]) Recover(h Func[error, bool]) BiPredicate[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, bool, error) {
+		return recoverWith(ctx, func(ctx context.Context) (context.Context, bool, error) {
+			return p(ctx, t, u)
+		}, h)
+	}
+}
+
+// MapError returns a BiPredicate that replaces the error of this predicate with the result of m, the error is
+// removed if m returns nil. The error of m is joined with the original error.
+func (p BiPredicate[T, U]) MapError(m Func[error, error]) BiPredicate[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, bool, error) {
+		return mapError(ctx, func(ctx context.Context) (context.Context, bool, error) {
+			return p(ctx, t, u)
+		}, m)
+	}
+}
+
+// OrElseGet returns a BiPredicate that substitutes the result of other for the error of this predicate.
+func (p BiPredicate[T, U]) OrElseGet(other Supplier[bool]) BiPredicate[T, U] {
+	return p.Recover(orElseGet(other))
+}
+
+// Fallback returns a Consumer that performs secondary on the arguments if this operation returns an error that when
+// evaluates to true, or any error if when is nil. The context returned by this operation is passed to secondary.
+func (c Consumer[T]) Fallback(secondary Consumer[T], when Predicate[error]) Consumer[T] {
+	return func(ctx context.Context, t T) (context.Context, error) {
+		ctx, _, err := fallback(ctx, consumerAttempt(func(ctx context.Context) (context.Context, error) {
+			return c(ctx, t)
+		}), consumerAttempt(func(ctx context.Context) (context.Context, error) {
+			return secondary(ctx, t)
+		}), when)
+		return ctx, err
+	}
+}
+
+// Recover returns a Consumer that performs h on the error of this operation instead of returning it.
+func (c Consumer[T]) Recover(h Consumer[error]) Consumer[T] {
+	return func(ctx context.Context, t T) (context.Context, error) {
+		ctx, _, err := recoverWith(ctx, consumerAttempt(func(ctx context.Context) (context.Context, error) {
+			return c(ctx, t)
+		}), consumerRecovery(h))
+		return ctx, err
+	}
+}
+
+// MapError returns a Consumer that replaces the error of this operation with the result of m, the error is removed if m
+// returns nil. The error of m is joined with the original error.
+func (c Consumer[T]) MapError(m Func[error, error]) Consumer[T] {
+	return func(ctx context.Context, t T) (context.Context, error) {
+		ctx, _, err := mapError(ctx, consumerAttempt(func(ctx context.Context) (context.Context, error) {
+			return c(ctx, t)
+		}), m)
+		return ctx, err
+	}
+}
+
+// Fallback returns a BiConsumer that performs secondary on the arguments if this operation returns an error that when
+// evaluates to true, or any error if when is nil. The context returned by this operation is passed to secondary.
+func (c BiConsumer[T, U]) Fallback(secondary BiConsumer[T, U], when Predicate[error]) BiConsumer[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, error) {
+		ctx, _, err := fallback(ctx, consumerAttempt(func(ctx context.Context) (context.Context, error) {
+			return c(ctx, t, u)
+		}), consumerAttempt(func(ctx context.Context) (context.Context, error) {
+			return secondary(ctx, t, u)
+		}), when)
+		return ctx, err
+	}
+}
+
+// Recover returns a BiConsumer that performs h on the error of this operation instead of returning it.
+func (c BiConsumer[T, U]) Recover(h Consumer[error]) BiConsumer[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, error) {
+		ctx, _, err := recoverWith(ctx, consumerAttempt(func(ctx context.Context) (context.Context, error) {
+			return c(ctx, t, u)
+		}), consumerRecovery(h))
+		return ctx, err
+	}
+}
+
+// MapError returns a BiConsumer that replaces the error of this operation with the result of m, the error is
+// removed if m returns nil. The error of m is joined with the original error.
+func (c BiConsumer[T, U]) MapError(m Func[error, error]) BiConsumer[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, error) {
+		ctx, _, err := mapError(ctx, consumerAttempt(func(ctx context.Context) (context.Context, error) {
+			return c(ctx, t, u)
+		}), m)
+		return ctx, err
+	}
+}
@@ -0,0 +1,165 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Fallback", func() {
+	errRetryable := errors.New("retryable")
+	errFatal := errors.New("fatal")
+	failWith := func(err error) funk.Func[int, int] {
+		return func(ctx context.Context, i int) (context.Context, int, error) {
+			return incCtxValue(ctx), 0, err
+		}
+	}
+	double := funk.Func[int, int](func(ctx context.Context, i int) (context.Context, int, error) {
+		return incCtxValue(ctx), i * 2, nil
+	})
+	retryable := funk.PureMustPredicate[error](func(err error) bool {
+		return errors.Is(err, errRetryable)
+	}).Lift()
+
+	Describe("Func", func() {
+		It("should not call secondary on success", func() {
+			ctx, v, err := double.Fallback(failWith(errFatal), nil)(context.Background(), 2)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal(4))
+			Expect(getCtxValue(ctx)).To(Equal(1))
+		})
+		It("should call secondary with the context of primary", func() {
+			ctx, v, err := failWith(errRetryable).Fallback(double, retryable)(context.Background(), 2)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal(4))
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
+		It("should return the error of primary if when evaluates to false", func() {
+			_, _, err := failWith(errFatal).Fallback(double, retryable)(context.Background(), 2)
+			Expect(err).To(Equal(errFatal))
+		})
+		It("should join the error of when", func() {
+			errWhen := errors.New("when")
+			when := funk.Predicate[error](func(ctx context.Context, err error) (context.Context, bool, error) {
+				return ctx, false, errWhen
+			})
+			_, _, err := failWith(errFatal).Fallback(double, when)(context.Background(), 2)
+			Expect(err).To(MatchError(errFatal))
+			Expect(err).To(MatchError(errWhen))
+		})
+		It("should recover", func() {
+			f := failWith(errFatal).Recover(func(ctx context.Context, err error) (context.Context, int, error) {
+				Expect(err).To(Equal(errFatal))
+				return incCtxValue(ctx), -1, nil
+			})
+			ctx, v, err := f(context.Background(), 2)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal(-1))
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
+		It("should map errors", func() {
+			errMapped := errors.New("mapped")
+			f := failWith(errFatal).MapError(funk.PureMustFunc[error, error](func(err error) error {
+				return errMapped
+			}).Lift())
+			_, _, err := f(context.Background(), 2)
+			Expect(err).To(Equal(errMapped))
+			_, v, err := double.MapError(nil)(context.Background(), 2)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal(4))
+		})
+		It("should remove errors mapped to nil", func() {
+			f := failWith(errFatal).MapError(funk.PureMustFunc[error, error](func(err error) error {
+				return nil
+			}).Lift())
+			_, _, err := f(context.Background(), 2)
+			Expect(err).To(Not(HaveOccurred()))
+		})
+		It("should join the error of mapping", func() {
+			errMapping := errors.New("mapping")
+			f := failWith(errFatal).MapError(func(ctx context.Context, err error) (context.Context, error, error) {
+				return ctx, nil, errMapping
+			})
+			_, _, err := f(context.Background(), 2)
+			Expect(err).To(MatchError(errFatal))
+			Expect(err).To(MatchError(errMapping))
+		})
+		It("should get the alternative", func() {
+			_, v, err := failWith(errFatal).OrElseGet(funk.Constant(7))(context.Background(), 2)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal(7))
+		})
+	})
+
+	It("should handle errors of Unary and BiFunc", func() {
+		u := funk.Unary[int](failWith(errRetryable)).Fallback(funk.Unary[int](double), nil)
+		_, v, err := u(context.Background(), 3)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(v).To(Equal(6))
+
+		b := funk.BiFunc[int, int, int](func(ctx context.Context, i, j int) (context.Context, int, error) {
+			return ctx, 0, errFatal
+		})
+		_, v, err = b.OrElseGet(funk.Constant(5))(context.Background(), 1, 2)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(v).To(Equal(5))
+	})
+
+	It("should handle errors of Supplier", func() {
+		s := funk.Failing[int](errRetryable).Fallback(funk.Constant(1), retryable)
+		_, v, err := s(context.Background())
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(v).To(Equal(1))
+
+		_, _, err = funk.Failing[int](errFatal).MapError(funk.PureMustFunc[error, error](func(err error) error {
+			return errRetryable
+		}).Lift())(context.Background())
+		Expect(err).To(Equal(errRetryable))
+	})
+
+	It("should handle errors of Predicate and BiPredicate", func() {
+		p := funk.Predicate[int](func(ctx context.Context, i int) (context.Context, bool, error) {
+			return ctx, false, errFatal
+		})
+		_, ok, err := p.OrElseGet(funk.Constant(true))(context.Background(), 1)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(ok).To(BeTrue())
+
+		bp := funk.BiPredicate[int, int](func(ctx context.Context, i, j int) (context.Context, bool, error) {
+			return ctx, false, errRetryable
+		})
+		_, ok, err = bp.Fallback(funk.PureMustBiPredicate[int, int](func(i, j int) bool { return i < j }).Lift(), retryable)(
+			context.Background(), 1, 2)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should handle errors of Consumer and BiConsumer", func() {
+		var handled error
+		c := funk.Consumer[int](func(ctx context.Context, i int) (context.Context, error) {
+			return incCtxValue(ctx), errFatal
+		})
+		ctx, err := c.Recover(func(ctx context.Context, err error) (context.Context, error) {
+			handled = err
+			return incCtxValue(ctx), nil
+		})(context.Background(), 1)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(handled).To(Equal(errFatal))
+		Expect(getCtxValue(ctx)).To(Equal(2))
+
+		var called bool
+		bc := funk.BiConsumer[int, int](func(ctx context.Context, i, j int) (context.Context, error) {
+			return ctx, errFatal
+		})
+		_, err = bc.Fallback(func(ctx context.Context, i, j int) (context.Context, error) {
+			called = true
+			return ctx, nil
+		}, retryable)(context.Background(), 1, 2)
+		Expect(err).To(Equal(errFatal))
+		Expect(called).To(BeFalse())
+	})
+})
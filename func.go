@@ -54,6 +54,29 @@ func (f PureFunc[T, R]) Must() PureMustFunc[T, R] {
 	}
 }
 
+// Lift returns a Func that passes the context through and never returns error.
+func (f MustFunc[T, R]) Lift() Func[T, R] {
+	return func(ctx context.Context, t T) (context.Context, R, error) {
+		ctx, v := f(ctx, t)
+		return ctx, v, nil
+	}
+}
+
+// Lift returns a Func that passes the context through untouched.
+func (f PureFunc[T, R]) Lift() Func[T, R] {
+	return func(ctx context.Context, t T) (context.Context, R, error) {
+		v, err := f(t)
+		return ctx, v, err
+	}
+}
+
+// Lift returns a Func that passes the context through untouched and never returns error.
+func (f PureMustFunc[T, R]) Lift() Func[T, R] {
+	return func(ctx context.Context, t T) (context.Context, R, error) {
+		return ctx, f(t), nil
+	}
+}
+
 // Unary represents a function on a single operand that produces a result of the same type as its operand.
 type Unary[T any] Func[T, T]
 
@@ -104,6 +127,29 @@ func (u PureUnary[T]) Must() PureMustUnary[T] {
 	}
 }
 
+// Lift returns a Unary that passes the context through and never returns error.
+func (u MustUnary[T]) Lift() Unary[T] {
+	return func(ctx context.Context, t T) (context.Context, T, error) {
+		ctx, v := u(ctx, t)
+		return ctx, v, nil
+	}
+}
+
+// Lift returns a Unary that passes the context through untouched.
+func (u PureUnary[T]) Lift() Unary[T] {
+	return func(ctx context.Context, t T) (context.Context, T, error) {
+		v, err := u(t)
+		return ctx, v, err
+	}
+}
+
+// Lift returns a Unary that passes the context through untouched and never returns error.
+func (u PureMustUnary[T]) Lift() Unary[T] {
+	return func(ctx context.Context, t T) (context.Context, T, error) {
+		return ctx, u(t), nil
+	}
+}
+
 // Then returns a composed Unary that first applies this unary to its input, and then applies the after unary to the result.
 func (u Unary[T]) Then(after Unary[T]) Unary[T] {
 	return func(ctx context.Context, t T) (context.Context, T, error) {
@@ -191,3 +237,26 @@ func (f PureBiFunc[T, U, R]) Must() PureMustBiFunc[T, U, R] {
 		return v
 	}
 }
+
+// Lift returns a BiFunc that passes the context through and never returns error.
+func (f MustBiFunc[T, U, R]) Lift() BiFunc[T, U, R] {
+	return func(ctx context.Context, t T, u U) (context.Context, R, error) {
+		ctx, v := f(ctx, t, u)
+		return ctx, v, nil
+	}
+}
+
+// Lift returns a BiFunc that passes the context through untouched.
+func (f PureBiFunc[T, U, R]) Lift() BiFunc[T, U, R] {
+	return func(ctx context.Context, t T, u U) (context.Context, R, error) {
+		v, err := f(t, u)
+		return ctx, v, err
+	}
+}
+
+// Lift returns a BiFunc that passes the context through untouched and never returns error.
+func (f PureMustBiFunc[T, U, R]) Lift() BiFunc[T, U, R] {
+	return func(ctx context.Context, t T, u U) (context.Context, R, error) {
+		return ctx, f(t, u), nil
+	}
+}
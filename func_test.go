@@ -616,3 +616,29 @@ var _ = Describe("PureMustUnary", func() {
 		})
 	})
 })
+
+var _ = Describe("Lifting functions", func() {
+	It("should lift MustFunc to Func", func() {
+		f := funk.MustFunc[int, string](func(ctx context.Context, i int) (context.Context, string) {
+			return incCtxValue(ctx), "1"
+		}).Lift()
+		ctx, v, err := f(context.Background(), 1)
+		Expect(getCtxValue(ctx)).To(Equal(1))
+		Expect(v).To(Equal("1"))
+		Expect(err).To(Not(HaveOccurred()))
+	})
+	It("should lift PureFunc to Func", func() {
+		f := funk.PureFunc[int, string](func(i int) (string, error) {
+			return "", errors.New("")
+		}).Lift()
+		ctx, _, err := f(incCtxValue(context.Background()), 1)
+		Expect(getCtxValue(ctx)).To(Equal(1))
+		Expect(err).To(HaveOccurred())
+	})
+	It("should lift PureMustUnary to Unary", func() {
+		u := funk.PureMustUnary[int](func(i int) int { return i + 1 }).Lift()
+		_, v, err := u(context.Background(), 1)
+		Expect(v).To(Equal(2))
+		Expect(err).To(Not(HaveOccurred()))
+	})
+})
@@ -1,6 +1,6 @@
 module github.com/hongcankun/gofunk
 
-go 1.18
+go 1.21
 
 require (
 	github.com/onsi/ginkgo/v2 v2.13.1
@@ -8,7 +8,7 @@ require (
 )
 
 require (
-	github.com/go-logr/logr v1.3.0 // indirect
+	github.com/go-logr/logr v1.4.1 // indirect
 	github.com/go-task/slim-sprig v0.0.0-20230315185526-52ccab3ef572 // indirect
 	github.com/google/go-cmp v0.6.0 // indirect
 	github.com/google/pprof v0.0.0-20210407192527-94a9f03dee38 // indirect
@@ -0,0 +1,151 @@
+package funk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// HedgeStats counts how often hedging triggered and won, it's safe for concurrent use.
+type HedgeStats struct {
+	calls     atomic.Uint64
+	triggered atomic.Uint64
+	hedges    atomic.Uint64
+	won       atomic.Uint64
+}
+
+// Calls returns the number of calls.
+func (s *HedgeStats) Calls() uint64 {
+	return s.calls.Load()
+}
+
+// Triggered returns the number of calls that started at least one hedge.
+func (s *HedgeStats) Triggered() uint64 {
+	return s.triggered.Load()
+}
+
+// Hedges returns the number of started hedges.
+func (s *HedgeStats) Hedges() uint64 {
+	return s.hedges.Load()
+}
+
+// Won returns the number of calls whose result came from a hedge rather than the first attempt.
+func (s *HedgeStats) Won() uint64 {
+	return s.won.Load()
+}
+
+type hedgeResult[R any] struct {
+	attempt  int
+	ctx      context.Context
+	r        R
+	err      error
+	panicked bool
+	p        any
+}
+
+// Hedge returns a Func that applies f, and starts up to maxHedges additional attempts when the running attempts
+// haven't succeeded after each delay. An attempt that fails starts the next one immediately. The first success is
+// returned with the values of the context returned by its attempt, and all attempts are cancelled through their
+// contexts, so the returned context is done only when the argument context is. If all attempts fail, it returns the
+// joined errors with the argument context.
+// If an attempt panics, the other attempts are cancelled and the panic is propagated to the caller.
+// It's for idempotent functions only, since several attempts may take effect. It panics if maxHedges is negative.
+func Hedge[T, R any](f Func[T, R], delay time.Duration, maxHedges int) (Func[T, R], *HedgeStats) {
+	return HedgeWithClock(f, delay, maxHedges, SystemClock)
+}
+
+// HedgeWithClock is Hedge that waits for the delays on clock.
+func HedgeWithClock[T, R any](f Func[T, R], delay time.Duration, maxHedges int, clock Clock) (Func[T, R], *HedgeStats) {
+	if maxHedges < 0 {
+		panic(fmt.Sprintf("funk: Hedge with negative maxHedges %d", maxHedges))
+	}
+	stats := &HedgeStats{}
+	return func(ctx context.Context, t T) (context.Context, R, error) {
+		stats.calls.Add(1)
+		results := make(chan hedgeResult[R], maxHedges+1)
+		var cancels []context.CancelFunc
+		cancelAll := func() {
+			for _, cancel := range cancels {
+				cancel()
+			}
+		}
+		start := func() {
+			if len(cancels) == 1 {
+				stats.triggered.Add(1)
+			}
+			if len(cancels) > 0 {
+				stats.hedges.Add(1)
+			}
+			actx, cancel := context.WithCancel(ctx)
+			attempt := len(cancels)
+			cancels = append(cancels, cancel)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						results <- hedgeResult[R]{attempt: attempt, panicked: true, p: p}
+					}
+				}()
+				ctx, r, err := f(actx, t)
+				results <- hedgeResult[R]{attempt: attempt, ctx: ctx, r: r, err: err}
+			}()
+		}
+
+		start()
+		elapsed := make(chan struct{}, 1)
+		wait := func() Timer {
+			return clock.AfterFunc(delay, func() { elapsed <- struct{}{} })
+		}
+		timer := wait()
+		defer func() { timer.Stop() }()
+		var errs []error
+		for {
+			select {
+			case res := <-results:
+				if res.panicked {
+					cancelAll()
+					panic(res.p)
+				}
+				if res.err == nil {
+					cancelAll()
+					if res.attempt > 0 {
+						stats.won.Add(1)
+					}
+					if res.ctx == nil {
+						return nil, res.r, nil
+					}
+					return hedgedContext{Context: ctx, values: res.ctx}, res.r, nil
+				}
+				errs = append(errs, res.err)
+				if len(cancels) <= maxHedges {
+					start()
+				} else if len(errs) == len(cancels) {
+					cancelAll()
+					var r R
+					return ctx, r, errors.Join(errs...)
+				}
+			case <-elapsed:
+				if len(cancels) <= maxHedges {
+					start()
+					timer = wait()
+				}
+			case <-ctx.Done():
+				cancelAll()
+				var r R
+				return ctx, r, ctx.Err()
+			}
+		}
+	}, stats
+}
+
+// hedgedContext is the context returned by a hedged call, it takes the values from the context returned by the winning
+// attempt, and the deadline and cancellation from the argument context, since the attempt has been cancelled.
+type hedgedContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c hedgedContext) Value(key any) any {
+	return c.values.Value(key)
+}
@@ -0,0 +1,177 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Hedge", func() {
+	type attemptKey struct{}
+	type result struct {
+		ctx context.Context
+		v   int
+		err error
+	}
+
+	var clock *funk.ManualClock
+	BeforeEach(func() {
+		clock = funk.NewManualClock(time.Unix(0, 0))
+	})
+
+	// call calls f in its own goroutine, since hedged calls wait for the clock.
+	call := func(ctx context.Context, f funk.Func[int, int], i int) chan result {
+		results := make(chan result, 1)
+		go func() {
+			ctx, v, err := f(ctx, i)
+			results <- result{ctx, v, err}
+		}()
+		return results
+	}
+
+	// elapse advances clock by d once the call is waiting for the delay.
+	elapse := func(d time.Duration) {
+		Eventually(clock.Pending).Should(Equal(1))
+		clock.Advance(d)
+	}
+
+	It("should not hedge fast calls", func() {
+		f, stats := funk.HedgeWithClock(funk.PureMustFunc[int, int](func(i int) int { return i * 2 }).Lift(),
+			time.Second, 2, clock)
+		_, v, err := f(context.Background(), 2)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(v).To(Equal(4))
+		Expect(clock.Pending()).To(Equal(0))
+		Expect(stats.Calls()).To(Equal(uint64(1)))
+		Expect(stats.Triggered()).To(Equal(uint64(0)))
+	})
+
+	It("should return the first success and cancel the losers", func() {
+		var attempts atomic.Int32
+		var winner context.Context
+		cancelled := make(chan error, 1)
+		slow := funk.Func[int, int](func(ctx context.Context, i int) (context.Context, int, error) {
+			attempt := attempts.Add(1)
+			if attempt == 1 {
+				<-ctx.Done()
+				cancelled <- ctx.Err()
+				return ctx, 0, ctx.Err()
+			}
+			winner = ctx
+			return context.WithValue(ctx, attemptKey{}, attempt), i * 2, nil
+		})
+		f, stats := funk.HedgeWithClock(slow, time.Second, 2, clock)
+		results := call(context.Background(), f, 2)
+		elapse(time.Second)
+		var res result
+		Eventually(results).Should(Receive(&res))
+		Expect(res.err).To(Not(HaveOccurred()))
+		Expect(res.v).To(Equal(4))
+		Expect(res.ctx.Value(attemptKey{})).To(Equal(int32(2)))
+		Expect(res.ctx.Err()).To(Not(HaveOccurred()))
+		Expect(winner.Err()).To(MatchError(context.Canceled))
+		Eventually(cancelled).Should(Receive(MatchError(context.Canceled)))
+		Expect(clock.Pending()).To(Equal(0))
+		Expect(stats.Triggered()).To(Equal(uint64(1)))
+		Expect(stats.Hedges()).To(Equal(uint64(1)))
+		Expect(stats.Won()).To(Equal(uint64(1)))
+	})
+
+	It("should start the next attempt when one fails", func() {
+		var attempts atomic.Int32
+		flaky := funk.Func[int, int](func(ctx context.Context, i int) (context.Context, int, error) {
+			if attempts.Add(1) == 1 {
+				return ctx, 0, errors.New("flaky")
+			}
+			return ctx, i, nil
+		})
+		f, stats := funk.HedgeWithClock(flaky, time.Hour, 1, clock)
+		_, v, err := f(context.Background(), 3)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(v).To(Equal(3))
+		Expect(stats.Won()).To(Equal(uint64(1)))
+	})
+
+	It("should return the joined errors if all attempts fail", func() {
+		var attempts atomic.Int32
+		failing := funk.Func[int, int](func(ctx context.Context, i int) (context.Context, int, error) {
+			attempts.Add(1)
+			return ctx, 0, errors.New("fail")
+		})
+		f, stats := funk.HedgeWithClock(failing, time.Hour, 2, clock)
+		_, _, err := f(context.Background(), 1)
+		Expect(err).To(MatchError("fail\nfail\nfail"))
+		Expect(attempts.Load()).To(Equal(int32(3)))
+		Expect(stats.Hedges()).To(Equal(uint64(2)))
+		Expect(stats.Won()).To(Equal(uint64(0)))
+	})
+
+	It("should stop when the context is done", func() {
+		var attempts atomic.Int32
+		blocking := funk.Func[int, int](func(ctx context.Context, i int) (context.Context, int, error) {
+			attempts.Add(1)
+			<-ctx.Done()
+			return ctx, 0, ctx.Err()
+		})
+		f, stats := funk.HedgeWithClock(blocking, time.Second, 3, clock)
+		ctx, cancel := context.WithCancel(context.Background())
+		results := call(ctx, f, 1)
+		elapse(time.Second)
+		elapse(time.Second)
+		Eventually(attempts.Load).Should(Equal(int32(3)))
+		cancel()
+		var res result
+		Eventually(results).Should(Receive(&res))
+		Expect(res.err).To(MatchError(context.Canceled))
+		Expect(stats.Hedges()).To(Equal(uint64(2)))
+	})
+
+	It("should propagate panics of attempts to the caller", func() {
+		var attempts atomic.Int32
+		cancelled := make(chan struct{})
+		panicking := funk.MustFunc[int, int](func(ctx context.Context, i int) (context.Context, int) {
+			if attempts.Add(1) == 1 {
+				<-ctx.Done()
+				close(cancelled)
+				return ctx, 0
+			}
+			panic("boom")
+		}).Lift()
+		f, _ := funk.HedgeWithClock(panicking, time.Second, 1, clock)
+		panics := make(chan any, 1)
+		go func() {
+			defer func() { panics <- recover() }()
+			_, _, _ = f(context.Background(), 1)
+		}()
+		elapse(time.Second)
+		Eventually(panics).Should(Receive(Equal("boom")))
+		Eventually(cancelled).Should(BeClosed())
+	})
+
+	It("should work with the system clock", func() {
+		blocking := funk.Func[int, int](func(ctx context.Context, i int) (context.Context, int, error) {
+			<-ctx.Done()
+			return ctx, 0, ctx.Err()
+		})
+		f, stats := funk.Hedge(blocking, time.Millisecond, 1)
+		ctx, cancel := context.WithCancel(context.Background())
+		results := call(ctx, f, 1)
+		Eventually(stats.Hedges).Should(Equal(uint64(1)))
+		cancel()
+		var res result
+		Eventually(results).Should(Receive(&res))
+		Expect(res.err).To(MatchError(context.Canceled))
+	})
+
+	It("should reject a negative maxHedges", func() {
+		Expect(func() {
+			funk.Hedge(funk.PureMustFunc[int, int](func(i int) int { return i }).Lift(), time.Second, -1)
+		}).To(PanicWith(ContainSubstring("negative maxHedges")))
+	})
+})
@@ -0,0 +1,164 @@
+package funk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNotConverged is returned by Unary.FixedPoint when the iteration doesn't converge within the max iterations.
+var ErrNotConverged = errors.New("not converged")
+
+// Iterated represents the result of iterating a Unary.
+type Iterated[T any] struct {
+	// Value is the result of the last applied iteration.
+	Value T
+	// Iterations is the number of applied iterations.
+	Iterations int
+}
+
+// Repeat returns a Func that applies this unary n times, threading the context through each iteration.
+// It stops with the error of the context if the context is done before an iteration.
+func (u Unary[T]) Repeat(n int) Func[T, Iterated[T]] {
+	return func(ctx context.Context, t T) (context.Context, Iterated[T], error) {
+		it := Iterated[T]{Value: t}
+		for it.Iterations < n {
+			var err error
+			if ctx, err = u.iterate(ctx, &it); err != nil {
+				return ctx, it, err
+			}
+		}
+		return ctx, it, nil
+	}
+}
+
+// IterateWhile returns a Func that applies this unary as long as p evaluates to true on the current value,
+// threading the context through each evaluation and iteration.
+// It stops with the error of the context if the context is done before an iteration.
+func (u Unary[T]) IterateWhile(p Predicate[T]) Func[T, Iterated[T]] {
+	return func(ctx context.Context, t T) (context.Context, Iterated[T], error) {
+		it := Iterated[T]{Value: t}
+		for {
+			ctx2, v, err := p(ctx, it.Value)
+			ctx = ctx2
+			if err != nil || !v {
+				return ctx, it, err
+			}
+			if ctx, err = u.iterate(ctx, &it); err != nil {
+				return ctx, it, err
+			}
+		}
+	}
+}
+
+// IterateUntilStable returns a Func that applies this unary until eq evaluates to true on the previous and current
+// values, threading the context through each iteration and evaluation.
+// It stops with the error of the context if the context is done before an iteration.
+func (u Unary[T]) IterateUntilStable(eq BiPredicate[T, T]) Func[T, Iterated[T]] {
+	return u.fixedPoint(eq, -1)
+}
+
+// FixedPoint is IterateUntilStable with a guard, it stops with ErrNotConverged if the values are not stable after
+// max iterations. A max of 0 returns ErrNotConverged without applying this unary, and it panics if max is negative.
+func (u Unary[T]) FixedPoint(eq BiPredicate[T, T], max int) Func[T, Iterated[T]] {
+	if max < 0 {
+		panic(fmt.Sprintf("funk: FixedPoint with negative max %d", max))
+	}
+	return u.fixedPoint(eq, max)
+}
+
+// fixedPoint implements FixedPoint, a negative max means no guard.
+func (u Unary[T]) fixedPoint(eq BiPredicate[T, T], max int) Func[T, Iterated[T]] {
+	return func(ctx context.Context, t T) (context.Context, Iterated[T], error) {
+		it := Iterated[T]{Value: t}
+		for {
+			if it.Iterations == max {
+				return ctx, it, fmt.Errorf("%w after %d iterations", ErrNotConverged, max)
+			}
+			prev := it.Value
+			var err error
+			if ctx, err = u.iterate(ctx, &it); err != nil {
+				return ctx, it, err
+			}
+			ctx2, stable, err := eq(ctx, prev, it.Value)
+			ctx = ctx2
+			if err != nil || stable {
+				return ctx, it, err
+			}
+		}
+	}
+}
+
+// iterate applies this unary to the value of it once if the context is not done.
+func (u Unary[T]) iterate(ctx context.Context, it *Iterated[T]) (context.Context, error) {
+	if err := ctx.Err(); err != nil {
+		return ctx, err
+	}
+	ctx, v, err := u(ctx, it.Value)
+	if err != nil {
+		return ctx, err
+	}
+	it.Value = v
+	it.Iterations++
+	return ctx, nil
+}
+
+// Repeat is Unary.Repeat for MustUnary.
+func (u MustUnary[T]) Repeat(n int) MustFunc[T, Iterated[T]] {
+	return u.Lift().Repeat(n).Must()
+}
+
+// IterateWhile is Unary.IterateWhile for MustUnary.
+func (u MustUnary[T]) IterateWhile(p MustPredicate[T]) MustFunc[T, Iterated[T]] {
+	return u.Lift().IterateWhile(p.Lift()).Must()
+}
+
+// IterateUntilStable is Unary.IterateUntilStable for MustUnary.
+func (u MustUnary[T]) IterateUntilStable(eq MustBiPredicate[T, T]) MustFunc[T, Iterated[T]] {
+	return u.Lift().IterateUntilStable(eq.Lift()).Must()
+}
+
+// FixedPoint is Unary.FixedPoint for MustUnary, it panics with ErrNotConverged if the values are not stable.
+func (u MustUnary[T]) FixedPoint(eq MustBiPredicate[T, T], max int) MustFunc[T, Iterated[T]] {
+	return u.Lift().FixedPoint(eq.Lift(), max).Must()
+}
+
+// Repeat is Unary.Repeat for PureUnary.
+func (u PureUnary[T]) Repeat(n int) PureFunc[T, Iterated[T]] {
+	return u.Lift().Repeat(n).Pure()
+}
+
+// IterateWhile is Unary.IterateWhile for PureUnary.
+func (u PureUnary[T]) IterateWhile(p PurePredicate[T]) PureFunc[T, Iterated[T]] {
+	return u.Lift().IterateWhile(p.Lift()).Pure()
+}
+
+// IterateUntilStable is Unary.IterateUntilStable for PureUnary.
+func (u PureUnary[T]) IterateUntilStable(eq PureBiPredicate[T, T]) PureFunc[T, Iterated[T]] {
+	return u.Lift().IterateUntilStable(eq.Lift()).Pure()
+}
+
+// FixedPoint is Unary.FixedPoint for PureUnary.
+func (u PureUnary[T]) FixedPoint(eq PureBiPredicate[T, T], max int) PureFunc[T, Iterated[T]] {
+	return u.Lift().FixedPoint(eq.Lift(), max).Pure()
+}
+
+// Repeat is Unary.Repeat for PureMustUnary.
+func (u PureMustUnary[T]) Repeat(n int) PureMustFunc[T, Iterated[T]] {
+	return u.Lift().Repeat(n).Must().Pure()
+}
+
+// IterateWhile is Unary.IterateWhile for PureMustUnary.
+func (u PureMustUnary[T]) IterateWhile(p PureMustPredicate[T]) PureMustFunc[T, Iterated[T]] {
+	return u.Lift().IterateWhile(p.Lift()).Must().Pure()
+}
+
+// IterateUntilStable is Unary.IterateUntilStable for PureMustUnary.
+func (u PureMustUnary[T]) IterateUntilStable(eq PureMustBiPredicate[T, T]) PureMustFunc[T, Iterated[T]] {
+	return u.Lift().IterateUntilStable(eq.Lift()).Must().Pure()
+}
+
+// FixedPoint is Unary.FixedPoint for PureMustUnary, it panics with ErrNotConverged if the values are not stable.
+func (u PureMustUnary[T]) FixedPoint(eq PureMustBiPredicate[T, T], max int) PureMustFunc[T, Iterated[T]] {
+	return u.Lift().FixedPoint(eq.Lift(), max).Must().Pure()
+}
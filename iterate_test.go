@@ -0,0 +1,124 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Iterating unary", func() {
+	inc := funk.Unary[int](func(ctx context.Context, i int) (context.Context, int, error) {
+		return incCtxValue(ctx), i + 1, nil
+	})
+
+	equal := funk.PureMustBiPredicate[int, int](func(a, b int) bool { return a == b }).Lift()
+
+	Describe("Repeat", func() {
+		It("should apply the unary n times", func() {
+			ctx, it, err := inc.Repeat(3)(context.Background(), 10)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(it).To(Equal(funk.Iterated[int]{Value: 13, Iterations: 3}))
+			Expect(getCtxValue(ctx)).To(Equal(3))
+		})
+		It("should not apply the unary if n isn't positive", func() {
+			_, it, _ := inc.Repeat(0)(context.Background(), 10)
+			Expect(it).To(Equal(funk.Iterated[int]{Value: 10}))
+		})
+		It("should stop on errors", func() {
+			u := inc.Then(func(ctx context.Context, i int) (context.Context, int, error) {
+				if i == 12 {
+					return ctx, 0, errors.New("")
+				}
+				return ctx, i, nil
+			})
+			_, it, err := u.Repeat(5)(context.Background(), 10)
+			Expect(err).To(HaveOccurred())
+			Expect(it).To(Equal(funk.Iterated[int]{Value: 11, Iterations: 1}))
+		})
+	})
+
+	Describe("IterateWhile", func() {
+		It("should apply the unary while the predicate holds", func() {
+			ctx, it, err := inc.IterateWhile(funk.Lt(5).Lift())(context.Background(), 0)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(it).To(Equal(funk.Iterated[int]{Value: 5, Iterations: 5}))
+			Expect(getCtxValue(ctx)).To(Equal(5))
+		})
+		It("should stop when the context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			u := inc.Then(func(ctx context.Context, i int) (context.Context, int, error) {
+				if i == 3 {
+					cancel()
+				}
+				return ctx, i, nil
+			})
+			_, it, err := u.IterateWhile(funk.Gt(-1).Lift())(ctx, 0)
+			Expect(err).To(MatchError(context.Canceled))
+			Expect(it).To(Equal(funk.Iterated[int]{Value: 3, Iterations: 3}))
+		})
+	})
+
+	Describe("Converging", func() {
+		sqrt2 := funk.Unary[float64](func(ctx context.Context, x float64) (context.Context, float64, error) {
+			return ctx, (x + 2/x) / 2, nil
+		})
+		near := funk.PureMustBiPredicate[float64, float64](func(a, b float64) bool {
+			return math.Abs(a-b) < 1e-9
+		}).Lift()
+
+		It("should iterate until stable", func() {
+			_, it, err := sqrt2.IterateUntilStable(near)(context.Background(), 1)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(it.Value).To(BeNumerically("~", math.Sqrt2, 1e-9))
+			Expect(it.Iterations).To(BeNumerically("<", 10))
+		})
+		It("should find the fixed point", func() {
+			_, it, err := sqrt2.FixedPoint(near, 10)(context.Background(), 1)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(it.Value).To(BeNumerically("~", math.Sqrt2, 1e-9))
+		})
+		It("should return ErrNotConverged after max iterations", func() {
+			_, it, err := inc.FixedPoint(funk.PureMustBiPredicate[int, int](func(a, b int) bool {
+				return a == b
+			}).Lift(), 100)(context.Background(), 0)
+			Expect(errors.Is(err, funk.ErrNotConverged)).To(BeTrue())
+			Expect(it).To(Equal(funk.Iterated[int]{Value: 100, Iterations: 100}))
+		})
+		It("should not apply the unary if max is 0", func() {
+			ctx, it, err := inc.FixedPoint(equal, 0)(context.Background(), 0)
+			Expect(err).To(MatchError(funk.ErrNotConverged))
+			Expect(it).To(Equal(funk.Iterated[int]{Value: 0, Iterations: 0}))
+			Expect(getCtxValue(ctx)).To(Equal(0))
+		})
+		It("should panic if max is negative", func() {
+			Expect(func() { inc.FixedPoint(equal, -1) }).To(Panic())
+		})
+	})
+
+	Describe("Other variants", func() {
+		pureInc := funk.PureMustUnary[int](func(i int) int { return i + 1 })
+		halve := funk.PureMustUnary[int](func(i int) int { return i / 2 })
+
+		It("should support MustUnary", func() {
+			_, it := pureInc.Lift().Must().Repeat(3)(context.Background(), 0)
+			Expect(it).To(Equal(funk.Iterated[int]{Value: 3, Iterations: 3}))
+			Expect(func() { halve.Lift().Must().FixedPoint(equal.Must(), 2)(context.Background(), 1024) }).To(Panic())
+		})
+		It("should support PureUnary", func() {
+			it, err := pureInc.Lift().Pure().IterateWhile(funk.Lt(5).Lift().Pure())(0)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(it.Value).To(Equal(5))
+		})
+		It("should support PureMustUnary", func() {
+			it := halve.IterateUntilStable(funk.PureMustBiPredicate[int, int](func(a, b int) bool { return a == b }))(1024)
+			Expect(it).To(Equal(funk.Iterated[int]{Value: 0, Iterations: 12}))
+			it = halve.FixedPoint(funk.PureMustBiPredicate[int, int](func(a, b int) bool { return a == b }), 20)(8)
+			Expect(it.Value).To(Equal(0))
+		})
+	})
+})
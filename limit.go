@@ -0,0 +1,213 @@
+package funk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrLimitExceeded is returned by limited functions in LimitReject mode when the limiter doesn't permit the call.
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// LimitMode represents how limited functions behave when the limiter doesn't permit a call.
+type LimitMode int
+
+const (
+	// LimitWait waits until the limiter permits the call or the context is done.
+	LimitWait LimitMode = iota
+	// LimitReject fails the call with ErrLimitExceeded immediately.
+	LimitReject
+)
+
+// Limiter permits calls, a Limiter can be shared by several limited functions to limit them together.
+type Limiter interface {
+	// Acquire returns a release function once the call is permitted, which must be called after the call finishes.
+	// If wait is false, it returns ErrLimitExceeded rather than waiting, otherwise it returns the error of ctx if ctx
+	// is done before the call is permitted.
+	Acquire(ctx context.Context, wait bool) (release func(), err error)
+}
+
+// Limited returns a Middleware that performs calls only when l permits them.
+func Limited(l Limiter, mode LimitMode) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, inv *Invocation) (context.Context, any, error) {
+			release, err := l.Acquire(ctx, mode == LimitWait)
+			if err != nil {
+				return ctx, nil, err
+			}
+			defer release()
+			return next(ctx, inv)
+		}
+	}
+}
+
+// TokenBucket is a Limiter that permits calls at rate per second on average, with bursts of up to burst calls.
+// It's safe for concurrent use.
+type TokenBucket struct {
+	mu     sync.Mutex
+	clock  Clock
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket returns a full TokenBucket, it panics if rate or burst isn't positive.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if !(rate > 0) {
+		panic(fmt.Sprintf("funk: TokenBucket with non-positive rate %v", rate))
+	}
+	if burst <= 0 {
+		panic(fmt.Sprintf("funk: TokenBucket with non-positive burst %d", burst))
+	}
+	return &TokenBucket{clock: SystemClock, rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// WithClock makes this bucket use c instead of SystemClock, and refills it from the time of c. It must be called before
+// use.
+func (b *TokenBucket) WithClock(c Clock) *TokenBucket {
+	b.clock = c
+	b.last = c.Now()
+	return b
+}
+
+// Acquire implements Limiter. Waiting calls reserve tokens in order, and a reservation is given back if the context
+// is done before the token is available.
+func (b *TokenBucket) Acquire(ctx context.Context, wait bool) (func(), error) {
+	b.mu.Lock()
+	now := b.clock.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		b.mu.Unlock()
+		return noRelease, nil
+	}
+	if !wait {
+		b.mu.Unlock()
+		return nil, ErrLimitExceeded
+	}
+	delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	b.tokens--
+	b.mu.Unlock()
+
+	if err := sleep(ctx, b.clock, delay); err != nil {
+		b.mu.Lock()
+		b.tokens = math.Min(b.burst, b.tokens+1)
+		b.mu.Unlock()
+		return nil, err
+	}
+	return noRelease, nil
+}
+
+// FixedWindow is a Limiter that permits up to limit calls in each window of time. It's safe for concurrent use.
+type FixedWindow struct {
+	mu     sync.Mutex
+	clock  Clock
+	limit  int
+	window time.Duration
+	start  time.Time
+	count  int
+}
+
+// NewFixedWindow returns a FixedWindow whose first window starts now, it panics if limit or window isn't positive.
+func NewFixedWindow(limit int, window time.Duration) *FixedWindow {
+	if limit <= 0 {
+		panic(fmt.Sprintf("funk: FixedWindow with non-positive limit %d", limit))
+	}
+	if window <= 0 {
+		panic(fmt.Sprintf("funk: FixedWindow with non-positive window %v", window))
+	}
+	return &FixedWindow{clock: SystemClock, limit: limit, window: window, start: time.Now()}
+}
+
+// WithClock makes this limiter use c instead of SystemClock, and starts the first window at the time of c. It must be
+// called before use.
+func (w *FixedWindow) WithClock(c Clock) *FixedWindow {
+	w.clock = c
+	w.start = c.Now()
+	return w
+}
+
+// Acquire implements Limiter, waiting calls wait for the next window.
+func (w *FixedWindow) Acquire(ctx context.Context, wait bool) (func(), error) {
+	for {
+		w.mu.Lock()
+		now := w.clock.Now()
+		if elapsed := now.Sub(w.start); elapsed >= w.window {
+			w.start = now.Add(-elapsed % w.window)
+			w.count = 0
+		}
+		if w.count < w.limit {
+			w.count++
+			w.mu.Unlock()
+			return noRelease, nil
+		}
+		next := w.start.Add(w.window)
+		w.mu.Unlock()
+		if !wait {
+			return nil, ErrLimitExceeded
+		}
+		if err := sleep(ctx, w.clock, next.Sub(now)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Bulkhead is a Limiter that permits up to max concurrent calls. It's safe for concurrent use.
+type Bulkhead struct {
+	slots chan struct{}
+}
+
+// NewBulkhead returns an empty Bulkhead, it panics if max isn't positive.
+func NewBulkhead(max int) *Bulkhead {
+	if max <= 0 {
+		panic(fmt.Sprintf("funk: Bulkhead with non-positive max %d", max))
+	}
+	return &Bulkhead{slots: make(chan struct{}, max)}
+}
+
+// Acquire implements Limiter, the release function frees the slot of the call.
+func (b *Bulkhead) Acquire(ctx context.Context, wait bool) (func(), error) {
+	if !wait {
+		select {
+		case b.slots <- struct{}{}:
+			return b.release, nil
+		default:
+			return nil, ErrLimitExceeded
+		}
+	}
+	select {
+	case b.slots <- struct{}{}:
+		return b.release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// InFlight returns the number of calls holding slots.
+func (b *Bulkhead) InFlight() int {
+	return len(b.slots)
+}
+
+func (b *Bulkhead) release() {
+	<-b.slots
+}
+
+func noRelease() {}
+
+// sleep waits for d on c or until ctx is done, and returns the error of ctx in the latter case.
+func sleep(ctx context.Context, c Clock, d time.Duration) error {
+	elapsed := make(chan struct{})
+	t := c.AfterFunc(d, func() { close(elapsed) })
+	defer t.Stop()
+	select {
+	case <-elapsed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,172 @@
+package funk_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Limiting", func() {
+	double := funk.PureMustFunc[int, int](func(i int) int { return i * 2 }).Lift()
+
+	var clock *funk.ManualClock
+	BeforeEach(func() {
+		clock = funk.NewManualClock(time.Unix(0, 0))
+	})
+
+	// waitFor advances clock by d once a call is waiting on it.
+	waitFor := func(d time.Duration) {
+		Eventually(clock.Pending).WithTimeout(time.Second).Should(Equal(1))
+		clock.Advance(d)
+	}
+
+	Describe("TokenBucket", func() {
+		It("should reject calls beyond the burst", func() {
+			f := double.With(funk.Limited(funk.NewTokenBucket(1, 2).WithClock(clock), funk.LimitReject))
+			for i := 0; i < 2; i++ {
+				_, v, err := f(context.Background(), 1)
+				Expect(err).To(Not(HaveOccurred()))
+				Expect(v).To(Equal(2))
+			}
+			_, v, err := f(context.Background(), 1)
+			Expect(err).To(MatchError(funk.ErrLimitExceeded))
+			Expect(v).To(Equal(0))
+			clock.Advance(time.Second)
+			_, _, err = f(context.Background(), 1)
+			Expect(err).To(Not(HaveOccurred()))
+		})
+		It("should wait for tokens", func() {
+			f := double.With(funk.Limited(funk.NewTokenBucket(50, 1).WithClock(clock), funk.LimitWait))
+			_, _, err := f(context.Background(), 1)
+			Expect(err).To(Not(HaveOccurred()))
+			done := make(chan error, 1)
+			go func() {
+				_, _, err := f(context.Background(), 1)
+				done <- err
+			}()
+			waitFor(19 * time.Millisecond)
+			Consistently(done).Should(BeEmpty())
+			clock.Advance(time.Millisecond)
+			Eventually(done).Should(Receive(Not(HaveOccurred())))
+		})
+		It("should stop waiting when the context is done and give the token back", func() {
+			b := funk.NewTokenBucket(5, 1).WithClock(clock)
+			f := double.With(funk.Limited(b, funk.LimitWait))
+			_, _, _ = f(context.Background(), 1)
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() {
+				_, _, err := f(ctx, 1)
+				done <- err
+			}()
+			Eventually(clock.Pending).WithTimeout(time.Second).Should(Equal(1))
+			cancel()
+			Eventually(done).Should(Receive(MatchError(context.Canceled)))
+			Expect(clock.Pending()).To(Equal(0))
+			clock.Advance(200 * time.Millisecond)
+			_, err := b.Acquire(context.Background(), false)
+			Expect(err).To(Not(HaveOccurred()))
+		})
+		It("should be shared by several functions", func() {
+			b := funk.NewTokenBucket(1, 1)
+			f := double.With(funk.Limited(b, funk.LimitReject))
+			c := funk.PureMustConsumer[int](func(int) {}).Lift().With(funk.Limited(b, funk.LimitReject))
+			_, _, err := f(context.Background(), 1)
+			Expect(err).To(Not(HaveOccurred()))
+			_, err = c(context.Background(), 1)
+			Expect(err).To(MatchError(funk.ErrLimitExceeded))
+		})
+		It("should reject a non-positive rate", func() {
+			Expect(func() { funk.NewTokenBucket(0, 1) }).To(PanicWith(ContainSubstring("non-positive rate")))
+			Expect(func() { funk.NewTokenBucket(-1, 1) }).To(PanicWith(ContainSubstring("non-positive rate")))
+		})
+		It("should reject a non-positive burst", func() {
+			Expect(func() { funk.NewTokenBucket(1, 0) }).To(PanicWith(ContainSubstring("non-positive burst")))
+			Expect(func() { funk.NewTokenBucket(1, -1) }).To(PanicWith(ContainSubstring("non-positive burst")))
+		})
+	})
+
+	Describe("FixedWindow", func() {
+		It("should permit limit calls per window", func() {
+			s := funk.Constant(1).With(funk.Limited(funk.NewFixedWindow(2, 30*time.Millisecond).WithClock(clock),
+				funk.LimitReject))
+			for i := 0; i < 2; i++ {
+				_, _, err := s(context.Background())
+				Expect(err).To(Not(HaveOccurred()))
+			}
+			_, _, err := s(context.Background())
+			Expect(err).To(MatchError(funk.ErrLimitExceeded))
+			clock.Advance(30 * time.Millisecond)
+			_, _, err = s(context.Background())
+			Expect(err).To(Not(HaveOccurred()))
+		})
+		It("should wait for the next window", func() {
+			f := funk.PureMustBiFunc[int, int, int](func(a, b int) int { return a + b }).Lift().
+				With(funk.Limited(funk.NewFixedWindow(1, 20*time.Millisecond).WithClock(clock), funk.LimitWait))
+			_, _, err := f(context.Background(), 1, 2)
+			Expect(err).To(Not(HaveOccurred()))
+			clock.Advance(5 * time.Millisecond)
+			done := make(chan int, 1)
+			go func() {
+				_, v, _ := f(context.Background(), 1, 2)
+				done <- v
+			}()
+			waitFor(14 * time.Millisecond)
+			Consistently(done).Should(BeEmpty())
+			clock.Advance(time.Millisecond)
+			Eventually(done).Should(Receive(Equal(3)))
+		})
+		It("should reject a non-positive window", func() {
+			Expect(func() { funk.NewFixedWindow(1, 0) }).To(PanicWith(ContainSubstring("non-positive window")))
+		})
+		It("should reject a non-positive limit", func() {
+			Expect(func() { funk.NewFixedWindow(0, time.Second) }).To(PanicWith(ContainSubstring("non-positive limit")))
+			Expect(func() { funk.NewFixedWindow(-1, time.Second) }).To(PanicWith(ContainSubstring("non-positive limit")))
+		})
+	})
+
+	Describe("Bulkhead", func() {
+		It("should limit concurrent calls", func() {
+			b := funk.NewBulkhead(2)
+			release := make(chan struct{})
+			entered := make(chan struct{}, 2)
+			blocking := funk.Consumer[int](func(ctx context.Context, i int) (context.Context, error) {
+				entered <- struct{}{}
+				<-release
+				return ctx, nil
+			})
+			waiting := blocking.With(funk.Limited(b, funk.LimitWait))
+			var wg sync.WaitGroup
+			for i := 0; i < 2; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, _ = waiting(context.Background(), 1)
+				}()
+			}
+			<-entered
+			<-entered
+			Expect(b.InFlight()).To(Equal(2))
+
+			_, err := blocking.With(funk.Limited(b, funk.LimitReject))(context.Background(), 1)
+			Expect(err).To(MatchError(funk.ErrLimitExceeded))
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err = waiting(ctx, 1)
+			Expect(err).To(MatchError(context.Canceled))
+
+			close(release)
+			wg.Wait()
+			Expect(b.InFlight()).To(Equal(0))
+		})
+		It("should reject a non-positive max", func() {
+			Expect(func() { funk.NewBulkhead(0) }).To(PanicWith(ContainSubstring("non-positive max")))
+			Expect(func() { funk.NewBulkhead(-1) }).To(PanicWith(ContainSubstring("non-positive max")))
+		})
+	})
+})
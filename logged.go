@@ -0,0 +1,128 @@
+package funk
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// LogOption configures the Middleware returned by Logged.
+type LogOption func(*logConfig)
+
+type logConfig struct {
+	name    string
+	success slog.Level
+	failure slog.Level
+	args    func(any) any
+	results func(any) any
+}
+
+// LogName makes the logs carry name, otherwise they carry the stage path of the context if any.
+func LogName(name string) LogOption {
+	return func(c *logConfig) {
+		c.name = name
+	}
+}
+
+// LogLevels sets the levels of the logs of successful and failed calls, which are Info and Error by default.
+// The start of calls is logged at the success level.
+func LogLevels(success, failure slog.Level) LogOption {
+	return func(c *logConfig) {
+		c.success, c.failure = success, failure
+	}
+}
+
+// LogArgs makes the logs carry the arguments, each argument is passed to redact before logging.
+// A nil redact logs the arguments as they are.
+func LogArgs(redact func(any) any) LogOption {
+	return func(c *logConfig) {
+		c.args = orIdentity(redact)
+	}
+}
+
+// LogResults makes the logs of successful calls carry the result, which is passed to redact before logging.
+// A nil redact logs the result as it is.
+func LogResults(redact func(any) any) LogOption {
+	return func(c *logConfig) {
+		c.results = orIdentity(redact)
+	}
+}
+
+// Logged returns a Middleware that logs the start and finish of calls to logger, with the duration and the error.
+// The logs carry the attributes added to the context by WithLogAttrs. Nothing is logged, redacted or timed if the
+// levels are disabled.
+func Logged(logger *slog.Logger, opts ...LogOption) Middleware {
+	cfg := logConfig{success: slog.LevelInfo, failure: slog.LevelError}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, inv *Invocation) (context.Context, any, error) {
+			success, failure := logger.Enabled(ctx, cfg.success), logger.Enabled(ctx, cfg.failure)
+			if !success && !failure {
+				return next(ctx, inv)
+			}
+			attrs := cfg.attrs(ctx, inv)
+			if success {
+				logger.LogAttrs(ctx, cfg.success, "start", attrs...)
+			}
+			start := time.Now()
+			ctx2, r, err := next(ctx, inv)
+			attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+			switch {
+			case err != nil && failure:
+				logger.LogAttrs(ctx, cfg.failure, "finish", append(attrs, slog.Any("error", err))...)
+			case err == nil && success:
+				if cfg.results != nil {
+					attrs = append(attrs, slog.Any("result", cfg.results(r)))
+				}
+				logger.LogAttrs(ctx, cfg.success, "finish", attrs...)
+			}
+			return ctx2, r, err
+		}
+	}
+}
+
+func (c *logConfig) attrs(ctx context.Context, inv *Invocation) []slog.Attr {
+	attrs := append([]slog.Attr(nil), LogAttrs(ctx)...)
+	name := c.name
+	if name == "" {
+		name = StagePath(ctx)
+	}
+	if name != "" {
+		attrs = append(attrs, slog.String("name", name))
+	}
+	attrs = append(attrs, slog.String("kind", string(inv.Kind)))
+	if c.args != nil {
+		args := make([]any, len(inv.Args))
+		for i, a := range inv.Args {
+			args[i] = c.args(a)
+		}
+		attrs = append(attrs, slog.Any("args", args))
+	}
+	return attrs
+}
+
+type logAttrsKey struct{}
+
+// WithLogAttrs returns a context that carries attrs in addition to the attributes carried by ctx, they are logged by
+// the Middleware returned by Logged.
+func WithLogAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	prev := LogAttrs(ctx)
+	return context.WithValue(ctx, logAttrsKey{}, append(prev[:len(prev):len(prev)], attrs...))
+}
+
+// LogAttrs returns the attributes carried by ctx.
+func LogAttrs(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(logAttrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+func orIdentity(f func(any) any) func(any) any {
+	if f != nil {
+		return f
+	}
+	return func(v any) any {
+		return v
+	}
+}
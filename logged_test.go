@@ -0,0 +1,122 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+type capturedLog struct {
+	Level slog.Level
+	Msg   string
+	Attrs map[string]any
+}
+
+type captureHandler struct {
+	level slog.Level
+	logs  *[]capturedLog
+}
+
+func (h captureHandler) Enabled(_ context.Context, l slog.Level) bool {
+	return l >= h.level
+}
+
+func (h captureHandler) Handle(_ context.Context, r slog.Record) error {
+	attrs := map[string]any{}
+	r.Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value.Any()
+		return true
+	})
+	*h.logs = append(*h.logs, capturedLog{Level: r.Level, Msg: r.Message, Attrs: attrs})
+	return nil
+}
+
+func (h captureHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h captureHandler) WithGroup(string) slog.Handler { return h }
+
+var _ = Describe("Logged", func() {
+	var logs []capturedLog
+	var logger *slog.Logger
+	BeforeEach(func() {
+		logs = nil
+		logger = slog.New(captureHandler{level: slog.LevelInfo, logs: &logs})
+	})
+
+	upper := funk.Func[string, string](func(ctx context.Context, s string) (context.Context, string, error) {
+		if s == "" {
+			return ctx, "", errors.New("empty")
+		}
+		return ctx, s + "!", nil
+	})
+
+	It("should log start and finish", func() {
+		f := upper.With(funk.Logged(logger, funk.LogName("upper")))
+		ctx := funk.WithLogAttrs(context.Background(), slog.String("request", "r1"))
+		_, v, err := f(ctx, "a")
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(v).To(Equal("a!"))
+		Expect(logs).To(HaveLen(2))
+		Expect(logs[0].Msg).To(Equal("start"))
+		Expect(logs[0].Level).To(Equal(slog.LevelInfo))
+		Expect(logs[0].Attrs).To(Equal(map[string]any{"request": "r1", "name": "upper", "kind": "Func"}))
+		Expect(logs[1].Msg).To(Equal("finish"))
+		Expect(logs[1].Attrs).To(HaveKeyWithValue("request", "r1"))
+		Expect(logs[1].Attrs).To(HaveKeyWithValue("duration", BeAssignableToTypeOf(time.Duration(0))))
+		Expect(logs[1].Attrs).To(Not(HaveKey("args")))
+		Expect(logs[1].Attrs).To(Not(HaveKey("result")))
+	})
+
+	It("should log errors at the failure level", func() {
+		f := upper.Stage("shout").With(funk.Logged(logger, funk.LogLevels(slog.LevelDebug, slog.LevelWarn)))
+		_, _, err := f(context.Background(), "")
+		Expect(err).To(HaveOccurred())
+		Expect(logs).To(HaveLen(1))
+		Expect(logs[0].Level).To(Equal(slog.LevelWarn))
+		Expect(logs[0].Attrs).To(HaveKeyWithValue("error", err))
+	})
+
+	It("should log the stage path as name", func() {
+		c := funk.Consumer[int](func(ctx context.Context, i int) (context.Context, error) {
+			return ctx, nil
+		}).With(funk.Logged(logger)).Stage("inner").Stage("outer")
+		_, _ = c(context.Background(), 1)
+		Expect(logs[0].Attrs).To(HaveKeyWithValue("name", "outer/inner"))
+	})
+
+	It("should log redacted arguments and results", func() {
+		redact := func(v any) any { return "***" }
+		f := upper.With(funk.Logged(logger, funk.LogArgs(redact), funk.LogResults(nil)))
+		_, _, _ = f(context.Background(), "secret")
+		Expect(logs[1].Attrs).To(HaveKeyWithValue("args", []any{"***"}))
+		Expect(logs[1].Attrs).To(HaveKeyWithValue("result", "secret!"))
+	})
+
+	It("should not log when the levels are disabled", func() {
+		redacted := false
+		redact := func(v any) any {
+			redacted = true
+			return v
+		}
+		f := upper.With(funk.Logged(logger, funk.LogLevels(slog.LevelDebug, slog.LevelDebug), funk.LogArgs(redact)))
+		_, v, _ := f(context.Background(), "a")
+		Expect(v).To(Equal("a!"))
+		Expect(logs).To(BeEmpty())
+		Expect(redacted).To(BeFalse())
+	})
+
+	It("should accumulate attributes in the context", func() {
+		ctx := funk.WithLogAttrs(context.Background(), slog.Int("a", 1))
+		ctx1 := funk.WithLogAttrs(ctx, slog.Int("b", 2))
+		ctx2 := funk.WithLogAttrs(ctx, slog.Int("c", 3))
+		Expect(funk.LogAttrs(ctx1)).To(HaveLen(2))
+		Expect(funk.LogAttrs(ctx2)[1].Key).To(Equal("c"))
+		Expect(funk.LogAttrs(ctx1)[1].Key).To(Equal("b"))
+	})
+})
@@ -0,0 +1,131 @@
+package funk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrNoMatch is returned by the functions compiled from Match when no case matches and there is no default.
+var ErrNoMatch = errors.New("no case matches")
+
+type matchCase[T, R any] struct {
+	predicate Predicate[T]
+	f         Func[T, R]
+}
+
+// Match represents a builder of a Func that dispatches its argument to the Func of the matched case, it replaces long
+// if/else chains of predicates.
+type Match[T, R any] struct {
+	cases []matchCase[T, R]
+	def   Func[T, R]
+}
+
+// NewMatch returns a Match without cases.
+func NewMatch[T, R any]() *Match[T, R] {
+	return &Match[T, R]{}
+}
+
+// Case adds a case that applies f if p evaluates to true.
+func (m *Match[T, R]) Case(p Predicate[T], f Func[T, R]) *Match[T, R] {
+	m.cases = append(m.cases, matchCase[T, R]{p, f})
+	return m
+}
+
+// CaseValue adds a case that applies f if the argument is equal to v, it panics if T is not comparable.
+// If T is an interface type, arguments whose dynamic values are not comparable never match.
+func (m *Match[T, R]) CaseValue(v T, f Func[T, R]) *Match[T, R] {
+	if typ := reflect.TypeOf((*T)(nil)).Elem(); !typ.Comparable() {
+		panic(fmt.Sprintf("funk: CaseValue on incomparable type %s", typ))
+	}
+	return m.Case(func(ctx context.Context, t T) (context.Context, bool, error) {
+		return ctx, comparableEqual(any(t), any(v)), nil
+	}, f)
+}
+
+// comparableEqual reports whether a == b, it's false rather than panicking if either dynamic value is not comparable.
+func comparableEqual(a, b any) bool {
+	return dynamicComparable(a) && dynamicComparable(b) && a == b
+}
+
+func dynamicComparable(x any) bool {
+	rv := reflect.ValueOf(x)
+	return !rv.IsValid() || rv.Comparable()
+}
+
+// Default sets f as the function applied if no case matches.
+func (m *Match[T, R]) Default(f Func[T, R]) *Match[T, R] {
+	m.def = f
+	return m
+}
+
+// First returns a Func that evaluates the predicates of cases in order, and applies the function of the first
+// matched case. The context returned by the matched predicate is passed to the function.
+// It applies the default function if no case matches, or returns ErrNoMatch if there is no default.
+// Cases added to the Match later don't affect the returned Func.
+func (m *Match[T, R]) First() Func[T, R] {
+	cases, def := m.snapshot()
+	return func(ctx context.Context, t T) (context.Context, R, error) {
+		for _, c := range cases {
+			ctx2, v, err := c.predicate(ctx, t)
+			ctx = ctx2
+			if err != nil {
+				var r R
+				return ctx, r, err
+			}
+			if v {
+				return c.f(ctx, t)
+			}
+		}
+		if def == nil {
+			var r R
+			return ctx, r, ErrNoMatch
+		}
+		return def(ctx, t)
+	}
+}
+
+// All returns a Func that evaluates the predicates of cases in order, and applies the functions of all matched cases
+// in order, threading the context through each evaluated predicate and applied function.
+// It applies the default function if no case matches, or returns ErrNoMatch if there is no default.
+// Cases added to the Match later don't affect the returned Func.
+func (m *Match[T, R]) All() Func[T, []R] {
+	cases, def := m.snapshot()
+	return func(ctx context.Context, t T) (context.Context, []R, error) {
+		var rs []R
+		for _, c := range cases {
+			ctx2, v, err := c.predicate(ctx, t)
+			ctx = ctx2
+			if err != nil {
+				return ctx, rs, err
+			}
+			if !v {
+				continue
+			}
+			ctx2, r, err := c.f(ctx, t)
+			ctx = ctx2
+			if err != nil {
+				return ctx, rs, err
+			}
+			rs = append(rs, r)
+		}
+		if rs != nil {
+			return ctx, rs, nil
+		}
+		if def == nil {
+			return ctx, nil, ErrNoMatch
+		}
+		ctx, r, err := def(ctx, t)
+		if err != nil {
+			return ctx, nil, err
+		}
+		return ctx, []R{r}, nil
+	}
+}
+
+func (m *Match[T, R]) snapshot() ([]matchCase[T, R], Func[T, R]) {
+	cases := make([]matchCase[T, R], len(m.cases))
+	copy(cases, m.cases)
+	return cases, m.def
+}
@@ -0,0 +1,118 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Match", func() {
+	constant := func(s string) funk.Func[int, string] {
+		return func(ctx context.Context, i int) (context.Context, string, error) {
+			return incCtxValue(ctx), s, nil
+		}
+	}
+	counted := func(p funk.PureMustPredicate[int]) funk.Predicate[int] {
+		return func(ctx context.Context, i int) (context.Context, bool, error) {
+			return incCtxValue(ctx), p(i), nil
+		}
+	}
+
+	var m *funk.Match[int, string]
+	BeforeEach(func() {
+		m = funk.NewMatch[int, string]().
+			Case(counted(funk.Lt(0)), constant("negative")).
+			CaseValue(0, constant("zero")).
+			Case(counted(funk.Lt(10)), constant("small"))
+	})
+
+	Describe("First match", func() {
+		It("should apply the function of the first matched case", func() {
+			ctx, v, err := m.First()(context.Background(), 0)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal("zero"))
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
+		It("should return ErrNoMatch without default", func() {
+			_, _, err := m.First()(context.Background(), 10)
+			Expect(errors.Is(err, funk.ErrNoMatch)).To(BeTrue())
+		})
+		It("should apply the default function", func() {
+			ctx, v, err := m.Default(constant("large")).First()(context.Background(), 10)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal("large"))
+			Expect(getCtxValue(ctx)).To(Equal(3))
+		})
+		It("should return errors of predicates", func() {
+			f := funk.NewMatch[int, string]().
+				Case(func(ctx context.Context, i int) (context.Context, bool, error) {
+					return ctx, true, errors.New("")
+				}, constant("")).
+				Default(constant("")).
+				First()
+			_, _, err := f(context.Background(), 0)
+			Expect(err).To(HaveOccurred())
+		})
+		It("should not be affected by later cases", func() {
+			f := m.First()
+			m.Default(constant("large"))
+			_, _, err := f(context.Background(), 10)
+			Expect(err).To(MatchError(funk.ErrNoMatch))
+		})
+	})
+
+	Describe("All match", func() {
+		It("should apply the functions of all matched cases", func() {
+			ctx, vs, err := m.All()(context.Background(), 0)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(vs).To(Equal([]string{"zero", "small"}))
+			Expect(getCtxValue(ctx)).To(Equal(4))
+		})
+		It("should apply the default function", func() {
+			_, vs, err := m.Default(constant("large")).All()(context.Background(), 10)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(vs).To(Equal([]string{"large"}))
+		})
+		It("should return ErrNoMatch without default", func() {
+			_, _, err := m.All()(context.Background(), 10)
+			Expect(err).To(MatchError(funk.ErrNoMatch))
+		})
+		It("should stop on errors of functions", func() {
+			f := m.Case(counted(funk.Gt(-10)), func(ctx context.Context, i int) (context.Context, string, error) {
+				return ctx, "", errors.New("")
+			}).All()
+			_, vs, err := f(context.Background(), 5)
+			Expect(err).To(HaveOccurred())
+			Expect(vs).To(Equal([]string{"small"}))
+		})
+	})
+
+	It("should panic on CaseValue of incomparable types", func() {
+		Expect(func() { funk.NewMatch[[]int, string]().CaseValue(nil, nil) }).To(Panic())
+	})
+
+	It("should not panic on CaseValue of incomparable dynamic values", func() {
+		named := func(s string) funk.Func[any, string] {
+			return funk.PureMustFunc[any, string](func(any) string { return s }).Lift()
+		}
+		f := funk.NewMatch[any, string]().
+			CaseValue([2]int{1, 2}, named("array")).
+			CaseValue([]int{1, 2}, named("slice")).
+			CaseValue(nil, named("nil")).
+			Default(named("other")).
+			First()
+		for arg, expected := range map[string]any{"array": [2]int{1, 2}, "other": []int{1, 2}} {
+			_, v, err := f(context.Background(), expected)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal(arg))
+		}
+		_, v, _ := f(context.Background(), map[string]int{})
+		Expect(v).To(Equal("other"))
+		_, v, _ = f(context.Background(), nil)
+		Expect(v).To(Equal("nil"))
+	})
+})
@@ -0,0 +1,304 @@
+package funk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultBuckets are the default upper bounds in seconds of the latency histograms of Metrics.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// MetricsRegistry provides the metrics of named stages.
+type MetricsRegistry interface {
+	// Stage returns the metrics of the stage named name, it's called once per decorated function.
+	Stage(name string) StageMetrics
+}
+
+// StageMetrics records the calls of a stage, its methods are called on the hot path and should be cheap.
+type StageMetrics interface {
+	// Start records the start of a call.
+	Start()
+	// Done records the end of a call with its duration and error.
+	Done(d time.Duration, err error)
+}
+
+// Instrumented returns a Middleware that records the calls to the metrics of the stage named name in r.
+// The Instrumented methods of the families record the same metrics without normalizing the calls.
+func Instrumented(r MetricsRegistry, name string) Middleware {
+	return func(next Handler) Handler {
+		m := r.Stage(name)
+		return func(ctx context.Context, inv *Invocation) (_ context.Context, _ any, err error) {
+			start := startCall(m)
+			defer func() { finishCall(m, start, recover(), err) }()
+			return next(ctx, inv)
+		}
+	}
+}
+
+// Instrumented returns a Func that records the calls to the metrics of the stage named name in r, like the
+// Middleware returned by Instrumented.
+func (f Func[T, R]) Instrumented(r MetricsRegistry, name string) Func[T, R] {
+	m := r.Stage(name)
+	return func(ctx context.Context, t T) (_ context.Context, _ R, err error) {
+		start := startCall(m)
+		defer func() { finishCall(m, start, recover(), err) }()
+		return f(ctx, t)
+	}
+}
+
+// Instrumented returns an Unary that records the calls to the metrics of the stage named name in r, like the
+// Middleware returned by Instrumented.
+func (u Unary[T]) Instrumented(r MetricsRegistry, name string) Unary[T] {
+	m := r.Stage(name)
+	return func(ctx context.Context, t T) (_ context.Context, _ T, err error) {
+		start := startCall(m)
+		defer func() { finishCall(m, start, recover(), err) }()
+		return u(ctx, t)
+	}
+}
+
+// Instrumented returns a BiFunc that records the calls to the metrics of the stage named name in r, like the
+// Middleware returned by Instrumented.
+func (f BiFunc[T, U, R]) Instrumented(r MetricsRegistry, name string) BiFunc[T, U, R] {
+	m := r.Stage(name)
+	return func(ctx context.Context, t T, u U) (_ context.Context, _ R, err error) {
+		start := startCall(m)
+		defer func() { finishCall(m, start, recover(), err) }()
+		return f(ctx, t, u)
+	}
+}
+
+// Instrumented returns a Supplier that records the calls to the metrics of the stage named name in r, like the
+// Middleware returned by Instrumented.
+func (s Supplier[T]) Instrumented(r MetricsRegistry, name string) Supplier[T] {
+	m := r.Stage(name)
+	return func(ctx context.Context) (_ context.Context, _ T, err error) {
+		start := startCall(m)
+		defer func() { finishCall(m, start, recover(), err) }()
+		return s(ctx)
+	}
+}
+
+// Instrumented returns a Consumer that records the calls to the metrics of the stage named name in r, like the
+// Middleware returned by Instrumented.
+func (c Consumer[T]) Instrumented(r MetricsRegistry, name string) Consumer[T] {
+	m := r.Stage(name)
+	return func(ctx context.Context, t T) (_ context.Context, err error) {
+		start := startCall(m)
+		defer func() { finishCall(m, start, recover(), err) }()
+		return c(ctx, t)
+	}
+}
+
+// Instrumented returns a BiConsumer that records the calls to the metrics of the stage named name in r, like the
+// Middleware returned by Instrumented.
+func (c BiConsumer[T, U]) Instrumented(r MetricsRegistry, name string) BiConsumer[T, U] {
+	m := r.Stage(name)
+	return func(ctx context.Context, t T, u U) (_ context.Context, err error) {
+		start := startCall(m)
+		defer func() { finishCall(m, start, recover(), err) }()
+		return c(ctx, t, u)
+	}
+}
+
+// Instrumented returns a Predicate that records the calls to the metrics of the stage named name in r, like the
+// Middleware returned by Instrumented.
+func (p Predicate[T]) Instrumented(r MetricsRegistry, name string) Predicate[T] {
+	m := r.Stage(name)
+	return func(ctx context.Context, t T) (_ context.Context, _ bool, err error) {
+		start := startCall(m)
+		defer func() { finishCall(m, start, recover(), err) }()
+		return p(ctx, t)
+	}
+}
+
+// Instrumented returns a BiPredicate that records the calls to the metrics of the stage named name in r, like the
+// Middleware returned by Instrumented.
+func (p BiPredicate[T, U]) Instrumented(r MetricsRegistry, name string) BiPredicate[T, U] {
+	m := r.Stage(name)
+	return func(ctx context.Context, t T, u U) (_ context.Context, _ bool, err error) {
+		start := startCall(m)
+		defer func() { finishCall(m, start, recover(), err) }()
+		return p(ctx, t, u)
+	}
+}
+
+// startCall records the start of a call to m and returns its start time.
+func startCall(m StageMetrics) time.Time {
+	m.Start()
+	return time.Now()
+}
+
+// finishCall records the end of a call started at start to m, and panics again with p if the call panicked.
+func finishCall(m StageMetrics, start time.Time, p any, err error) {
+	if p != nil {
+		m.Done(time.Since(start), fmt.Errorf("%w: %v", errPanicked, p))
+		panic(p)
+	}
+	m.Done(time.Since(start), err)
+}
+
+// errPanicked is recorded by Instrumented and Traced for calls that panic.
+var errPanicked = errors.New("panic")
+
+// ErrorClass classifies err as "canceled", "timeout", "panic" or "error", it's the default classifier of Metrics.
+func ErrorClass(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, errPanicked):
+		return "panic"
+	default:
+		return "error"
+	}
+}
+
+// Metrics is a MetricsRegistry that counts calls, errors by class and in-flight calls, and records latency
+// histograms of each stage. Recording is lock-free once the errors of a class have been seen, and the metrics can be
+// written in the Prometheus text exposition format.
+type Metrics struct {
+	prefix   string
+	buckets  []float64
+	classify func(error) string
+	stages   sync.Map
+}
+
+// NewMetrics returns an empty Metrics, the names of the metrics start with prefix, which is "funk" if empty.
+// The histograms use buckets as upper bounds in seconds, or DefaultBuckets if empty.
+func NewMetrics(prefix string, buckets ...float64) *Metrics {
+	if prefix == "" {
+		prefix = "funk"
+	}
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Metrics{prefix: prefix, buckets: buckets, classify: ErrorClass}
+}
+
+// WithClassifier makes this registry classify errors by classify instead of ErrorClass, it must be called before
+// recording.
+func (m *Metrics) WithClassifier(classify func(error) string) *Metrics {
+	m.classify = classify
+	return m
+}
+
+// Stage implements MetricsRegistry, stages of the same name share metrics.
+func (m *Metrics) Stage(name string) StageMetrics {
+	s, _ := m.stages.LoadOrStore(name, &stageMetrics{
+		registry: m,
+		buckets:  make([]atomic.Uint64, len(m.buckets)+1),
+	})
+	return s.(*stageMetrics)
+}
+
+type stageMetrics struct {
+	registry *Metrics
+	calls    atomic.Uint64
+	inFlight atomic.Int64
+	nanos    atomic.Int64
+	buckets  []atomic.Uint64
+	errors   sync.Map
+}
+
+func (s *stageMetrics) Start() {
+	s.inFlight.Add(1)
+}
+
+func (s *stageMetrics) Done(d time.Duration, err error) {
+	s.inFlight.Add(-1)
+	s.calls.Add(1)
+	s.nanos.Add(int64(d))
+	seconds := d.Seconds()
+	i := sort.SearchFloat64s(s.registry.buckets, seconds)
+	s.buckets[i].Add(1)
+	if err != nil {
+		class := s.registry.classify(err)
+		c, ok := s.errors.Load(class)
+		if !ok {
+			c, _ = s.errors.LoadOrStore(class, new(atomic.Uint64))
+		}
+		c.(*atomic.Uint64).Add(1)
+	}
+}
+
+// WritePrometheus writes the metrics to w in the Prometheus text exposition format, ordered by stage name.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	var names []string
+	m.stages.Range(func(k, _ any) bool {
+		names = append(names, k.(string))
+		return true
+	})
+	sort.Strings(names)
+	stages := make([]*stageMetrics, len(names))
+	for i, name := range names {
+		s, _ := m.stages.Load(name)
+		stages[i] = s.(*stageMetrics)
+	}
+
+	var b strings.Builder
+	p := m.prefix
+	fmt.Fprintf(&b, "# HELP %s_calls_total Number of finished calls.\n# TYPE %s_calls_total counter\n", p, p)
+	for i, s := range stages {
+		fmt.Fprintf(&b, "%s_calls_total{stage=%s} %d\n", p, quoteLabel(names[i]), s.calls.Load())
+	}
+	fmt.Fprintf(&b, "# HELP %s_errors_total Number of failed calls by error class.\n# TYPE %s_errors_total counter\n", p, p)
+	for i, s := range stages {
+		var classes []string
+		s.errors.Range(func(k, _ any) bool {
+			classes = append(classes, k.(string))
+			return true
+		})
+		sort.Strings(classes)
+		for _, class := range classes {
+			c, _ := s.errors.Load(class)
+			fmt.Fprintf(&b, "%s_errors_total{stage=%s,class=%s} %d\n", p, quoteLabel(names[i]), quoteLabel(class),
+				c.(*atomic.Uint64).Load())
+		}
+	}
+	fmt.Fprintf(&b, "# HELP %s_in_flight Number of calls in flight.\n# TYPE %s_in_flight gauge\n", p, p)
+	for i, s := range stages {
+		fmt.Fprintf(&b, "%s_in_flight{stage=%s} %d\n", p, quoteLabel(names[i]), s.inFlight.Load())
+	}
+	fmt.Fprintf(&b, "# HELP %s_duration_seconds Latency of finished calls.\n# TYPE %s_duration_seconds histogram\n", p, p)
+	for i, s := range stages {
+		stage := quoteLabel(names[i])
+		var count uint64
+		for j := range s.buckets {
+			count += s.buckets[j].Load()
+			le := math.Inf(1)
+			if j < len(m.buckets) {
+				le = m.buckets[j]
+			}
+			fmt.Fprintf(&b, "%s_duration_seconds_bucket{stage=%s,le=%s} %d\n", p, stage, quoteLabel(formatFloat(le)), count)
+		}
+		fmt.Fprintf(&b, "%s_duration_seconds_sum{stage=%s} %s\n", p, stage,
+			formatFloat(time.Duration(s.nanos.Load()).Seconds()))
+		fmt.Fprintf(&b, "%s_duration_seconds_count{stage=%s} %d\n", p, stage, count)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func quoteLabel(v string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v) + `"`
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
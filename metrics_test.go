@@ -0,0 +1,168 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+type fakeStageMetrics struct {
+	started int
+	errs    []error
+}
+
+func (m *fakeStageMetrics) Start() { m.started++ }
+
+func (m *fakeStageMetrics) Done(_ time.Duration, err error) { m.errs = append(m.errs, err) }
+
+type fakeRegistry map[string]*fakeStageMetrics
+
+func (r fakeRegistry) Stage(name string) funk.StageMetrics {
+	if r[name] == nil {
+		r[name] = &fakeStageMetrics{}
+	}
+	return r[name]
+}
+
+var _ = Describe("Metrics", func() {
+	It("should record calls to the registry", func() {
+		r := fakeRegistry{}
+		cause := errors.New("")
+		c := funk.Consumer[error](func(ctx context.Context, err error) (context.Context, error) {
+			return ctx, err
+		}).With(funk.Instrumented(r, "send"))
+		_, _ = c(context.Background(), nil)
+		_, _ = c(context.Background(), cause)
+		Expect(r["send"].started).To(Equal(2))
+		Expect(r["send"].errs).To(Equal([]error{nil, cause}))
+	})
+
+	It("should record panics", func() {
+		r := fakeRegistry{}
+		s := funk.Supplier[int](func(ctx context.Context) (context.Context, int, error) {
+			panic("boom")
+		}).With(funk.Instrumented(r, "load"))
+		Expect(func() { _, _, _ = s(context.Background()) }).To(PanicWith("boom"))
+		Expect(r["load"].errs).To(HaveLen(1))
+		Expect(funk.ErrorClass(r["load"].errs[0])).To(Equal("panic"))
+	})
+
+	It("should record calls of every family without middleware", func() {
+		r := fakeRegistry{}
+		cause := errors.New("")
+		inc := funk.PureUnary[int](func(i int) (int, error) { return i + 1, nil }).Lift()
+		_, i, _ := inc.Instrumented(r, "inc")(context.Background(), 1)
+		Expect(i).To(Equal(2))
+		_, _ = funk.BiConsumer[int, int](func(ctx context.Context, _ int, _ int) (context.Context, error) {
+			return ctx, cause
+		}).Instrumented(r, "put")(context.Background(), 1, 2)
+		_, ok, _ := funk.Eq(1).Lift().Instrumented(r, "one")(context.Background(), 1)
+		Expect(ok).To(BeTrue())
+		Expect(r["inc"].errs).To(Equal([]error{nil}))
+		Expect(r["put"].errs).To(Equal([]error{cause}))
+		Expect(r["one"].started).To(Equal(1))
+
+		s := funk.Supplier[int](func(ctx context.Context) (context.Context, int, error) {
+			panic("boom")
+		}).Instrumented(r, "load")
+		Expect(func() { _, _, _ = s(context.Background()) }).To(PanicWith("boom"))
+		Expect(funk.ErrorClass(r["load"].errs[0])).To(Equal("panic"))
+	})
+
+	It("should classify errors", func() {
+		Expect(funk.ErrorClass(context.Canceled)).To(Equal("canceled"))
+		Expect(funk.ErrorClass(&funk.StageError{Err: context.DeadlineExceeded})).To(Equal("timeout"))
+		Expect(funk.ErrorClass(errors.New(""))).To(Equal("error"))
+	})
+
+	It("should write the Prometheus text format", func() {
+		m := funk.NewMetrics("app", 1, 0.5).WithClassifier(func(err error) string {
+			return err.Error()
+		})
+		f := funk.PureFunc[string, string](func(s string) (string, error) {
+			if s != "" {
+				return "", errors.New(s)
+			}
+			return s, nil
+		}).Lift()
+		g := f.With(funk.Instrumented(m, `a"b`))
+		_, _, _ = g(context.Background(), "")
+		_, _, _ = g(context.Background(), "bad")
+		_, _, _ = g(context.Background(), "bad")
+		_, _, _ = f.With(funk.Instrumented(m, "x"))(context.Background(), "")
+
+		var b strings.Builder
+		Expect(m.WritePrometheus(&b)).To(Succeed())
+		out := b.String()
+		Expect(out).To(ContainSubstring("# TYPE app_calls_total counter\n" +
+			"app_calls_total{stage=\"a\\\"b\"} 3\n" +
+			"app_calls_total{stage=\"x\"} 1\n"))
+		Expect(out).To(ContainSubstring("app_errors_total{stage=\"a\\\"b\",class=\"bad\"} 2\n"))
+		Expect(out).To(ContainSubstring("app_in_flight{stage=\"x\"} 0\n"))
+		Expect(out).To(ContainSubstring("# TYPE app_duration_seconds histogram\n" +
+			"app_duration_seconds_bucket{stage=\"a\\\"b\",le=\"0.5\"} 3\n" +
+			"app_duration_seconds_bucket{stage=\"a\\\"b\",le=\"1\"} 3\n" +
+			"app_duration_seconds_bucket{stage=\"a\\\"b\",le=\"+Inf\"} 3\n" +
+			"app_duration_seconds_sum{stage=\"a\\\"b\"} "))
+		Expect(out).To(ContainSubstring("app_duration_seconds_count{stage=\"x\"} 1\n"))
+	})
+
+	It("should count calls in flight concurrently", func() {
+		m := funk.NewMetrics("")
+		release := make(chan struct{})
+		var started sync.WaitGroup
+		started.Add(10)
+		c := funk.Consumer[int](func(ctx context.Context, i int) (context.Context, error) {
+			started.Done()
+			<-release
+			return ctx, nil
+		}).With(funk.Instrumented(m, "wait"))
+		var done sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			done.Add(1)
+			go func() {
+				defer done.Done()
+				_, _ = c(context.Background(), 1)
+			}()
+		}
+		started.Wait()
+		var b strings.Builder
+		Expect(m.WritePrometheus(&b)).To(Succeed())
+		Expect(b.String()).To(ContainSubstring("funk_in_flight{stage=\"wait\"} 10\n"))
+		close(release)
+		done.Wait()
+		b.Reset()
+		Expect(m.WritePrometheus(&b)).To(Succeed())
+		Expect(b.String()).To(ContainSubstring("funk_in_flight{stage=\"wait\"} 0\n"))
+		Expect(b.String()).To(ContainSubstring("funk_calls_total{stage=\"wait\"} 10\n"))
+	})
+})
+
+func BenchmarkInstrumented(b *testing.B) {
+	inc := funk.Unary[int](func(ctx context.Context, i int) (context.Context, int, error) {
+		return ctx, i + 1, nil
+	})
+	ctx := context.Background()
+	b.Run("method", func(b *testing.B) {
+		u := inc.Instrumented(funk.NewMetrics(""), "inc")
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _, _ = u(ctx, i)
+		}
+	})
+	b.Run("middleware", func(b *testing.B) {
+		u := inc.With(funk.Instrumented(funk.NewMetrics(""), "inc"))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _, _ = u(ctx, i)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package funk
+
+import "context"
+
+// Kind represents the family of a function, such as Func or Consumer.
+type Kind string
+
+// Kinds of the families that can be decorated by Middleware.
+const (
+	KindFunc        Kind = "Func"
+	KindUnary       Kind = "Unary"
+	KindBiFunc      Kind = "BiFunc"
+	KindSupplier    Kind = "Supplier"
+	KindConsumer    Kind = "Consumer"
+	KindBiConsumer  Kind = "BiConsumer"
+	KindPredicate   Kind = "Predicate"
+	KindBiPredicate Kind = "BiPredicate"
+)
+
+// Invocation represents a normalized call of a function of any family.
+type Invocation struct {
+	// Kind is the family of the called function.
+	Kind Kind
+	// Args are the arguments of the call, e.g. the two arguments of a BiFunc, or none for a Supplier.
+	// Middlewares may replace them with values of the same types.
+	Args []any
+}
+
+// Handler represents a normalized function of any family. The result is nil for consumers, and a bool for
+// predicates.
+type Handler func(ctx context.Context, inv *Invocation) (context.Context, any, error)
+
+// Middleware decorates a Handler, so one implementation of a cross-cutting concern applies to every family through
+// the With methods.
+type Middleware func(next Handler) Handler
+
+// Chain returns a Middleware that applies ms in order, the first middleware is the outermost one.
+func Chain(ms ...Middleware) Middleware {
+	return func(h Handler) Handler {
+		for i := len(ms) - 1; i >= 0; i-- {
+			h = ms[i](h)
+		}
+		return h
+	}
+}
+
+// With returns a Func decorated by ms, the first middleware is the outermost one.
+func (f Func[T, R]) With(ms ...Middleware) Func[T, R] {
+	h := Chain(ms...)(func(ctx context.Context, inv *Invocation) (context.Context, any, error) {
+		ctx, r, err := f(ctx, invocationArg[T](inv, 0))
+		return ctx, r, err
+	})
+	return func(ctx context.Context, t T) (context.Context, R, error) {
+		ctx, r, err := h(ctx, &Invocation{Kind: KindFunc, Args: []any{t}})
+		return ctx, invocationResult[R](r), err
+	}
+}
+
+// With returns a Unary decorated by ms, the first middleware is the outermost one.
+func (u Unary[T]) With(ms ...Middleware) Unary[T] {
+	h := Chain(ms...)(func(ctx context.Context, inv *Invocation) (context.Context, any, error) {
+		ctx, r, err := u(ctx, invocationArg[T](inv, 0))
+		return ctx, r, err
+	})
+	return func(ctx context.Context, t T) (context.Context, T, error) {
+		ctx, r, err := h(ctx, &Invocation{Kind: KindUnary, Args: []any{t}})
+		return ctx, invocationResult[T](r), err
+	}
+}
+
+// With returns a BiFunc decorated by ms, the first middleware is the outermost one.
+func (f BiFunc[T, U, R]) With(ms ...Middleware) BiFunc[T, U, R] {
+	h := Chain(ms...)(func(ctx context.Context, inv *Invocation) (context.Context, any, error) {
+		ctx, r, err := f(ctx, invocationArg[T](inv, 0), invocationArg[U](inv, 1))
+		return ctx, r, err
+	})
+	return func(ctx context.Context, t T, u U) (context.Context, R, error) {
+		ctx, r, err := h(ctx, &Invocation{Kind: KindBiFunc, Args: []any{t, u}})
+		return ctx, invocationResult[R](r), err
+	}
+}
+
+// With returns a Supplier decorated by ms, the first middleware is the outermost one.
+func (s Supplier[T]) With(ms ...Middleware) Supplier[T] {
+	h := Chain(ms...)(func(ctx context.Context, inv *Invocation) (context.Context, any, error) {
+		ctx, r, err := s(ctx)
+		return ctx, r, err
+	})
+	return func(ctx context.Context) (context.Context, T, error) {
+		ctx, r, err := h(ctx, &Invocation{Kind: KindSupplier})
+		return ctx, invocationResult[T](r), err
+	}
+}
+
+// With returns a Consumer decorated by ms, the first middleware is the outermost one.
+func (c Consumer[T]) With(ms ...Middleware) Consumer[T] {
+	h := Chain(ms...)(func(ctx context.Context, inv *Invocation) (context.Context, any, error) {
+		ctx, err := c(ctx, invocationArg[T](inv, 0))
+		return ctx, nil, err
+	})
+	return func(ctx context.Context, t T) (context.Context, error) {
+		ctx, _, err := h(ctx, &Invocation{Kind: KindConsumer, Args: []any{t}})
+		return ctx, err
+	}
+}
+
+// With returns a BiConsumer decorated by ms, the first middleware is the outermost one.
+func (c BiConsumer[T, U]) With(ms ...Middleware) BiConsumer[T, U] {
+	h := Chain(ms...)(func(ctx context.Context, inv *Invocation) (context.Context, any, error) {
+		ctx, err := c(ctx, invocationArg[T](inv, 0), invocationArg[U](inv, 1))
+		return ctx, nil, err
+	})
+	return func(ctx context.Context, t T, u U) (context.Context, error) {
+		ctx, _, err := h(ctx, &Invocation{Kind: KindBiConsumer, Args: []any{t, u}})
+		return ctx, err
+	}
+}
+
+// With returns a Predicate decorated by ms, the first middleware is the outermost one.
+func (p Predicate[T]) With(ms ...Middleware) Predicate[T] {
+	h := Chain(ms...)(func(ctx context.Context, inv *Invocation) (context.Context, any, error) {
+		ctx, r, err := p(ctx, invocationArg[T](inv, 0))
+		return ctx, r, err
+	})
+	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		ctx, r, err := h(ctx, &Invocation{Kind: KindPredicate, Args: []any{t}})
+		return ctx, invocationResult[bool](r), err
+	}
+}
+
+// With returns a BiPredicate decorated by ms, the first middleware is the outermost one.
+func (p BiPredicate[T, U]) With(ms ...Middleware) BiPredicate[T, U] {
+	h := Chain(ms...)(func(ctx context.Context, inv *Invocation) (context.Context, any, error) {
+		ctx, r, err := p(ctx, invocationArg[T](inv, 0), invocationArg[U](inv, 1))
+		return ctx, r, err
+	})
+	return func(ctx context.Context, t T, u U) (context.Context, bool, error) {
+		ctx, r, err := h(ctx, &Invocation{Kind: KindBiPredicate, Args: []any{t, u}})
+		return ctx, invocationResult[bool](r), err
+	}
+}
+
+// invocationArg returns the i-th argument of inv as T, it panics if a middleware replaced it with a value of another type.
+func invocationArg[T any](inv *Invocation, i int) T {
+	if a := inv.Args[i]; a != nil {
+		return a.(T)
+	}
+	var t T
+	return t
+}
+
+// invocationResult returns r as T, or the zero value of T if r is nil.
+func invocationResult[T any](r any) T {
+	if r != nil {
+		return r.(T)
+	}
+	var t T
+	return t
+}
@@ -0,0 +1,102 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+type invocationRecord struct {
+	Kind   funk.Kind
+	Args   []any
+	Result any
+	Err    error
+}
+
+var _ = Describe("Middleware", func() {
+	var records []invocationRecord
+	var order []string
+	recording := funk.Middleware(func(next funk.Handler) funk.Handler {
+		return func(ctx context.Context, inv *funk.Invocation) (context.Context, any, error) {
+			ctx, r, err := next(ctx, inv)
+			records = append(records, invocationRecord{Kind: inv.Kind, Args: inv.Args, Result: r, Err: err})
+			return ctx, r, err
+		}
+	})
+	tagging := func(tag string) funk.Middleware {
+		return func(next funk.Handler) funk.Handler {
+			return func(ctx context.Context, inv *funk.Invocation) (context.Context, any, error) {
+				order = append(order, tag)
+				return next(incCtxValue(ctx), inv)
+			}
+		}
+	}
+	BeforeEach(func() {
+		records, order = nil, nil
+	})
+
+	It("should apply to every family", func() {
+		cause := errors.New("")
+		_, _, _ = funk.PureMustFunc[int, string](func(i int) string { return "a" }).Lift().With(recording)(context.Background(), 1)
+		_, _, _ = funk.PureMustUnary[int](func(i int) int { return i + 1 }).Lift().With(recording)(context.Background(), 1)
+		_, _, _ = funk.PureMustBiFunc[int, int, int](func(a, b int) int { return a + b }).Lift().With(recording)(context.Background(), 1, 2)
+		_, _, _ = funk.Failing[int](cause).With(recording)(context.Background())
+		_, _ = funk.PureMustConsumer[int](func(int) {}).Lift().With(recording)(context.Background(), 1)
+		_, _ = funk.PureMustBiConsumer[int, string](func(int, string) {}).Lift().With(recording)(context.Background(), 1, "a")
+		_, _, _ = funk.Eq(1).Lift().With(recording)(context.Background(), 1)
+		_, _, _ = funk.PureMustBiPredicate[int, int](func(a, b int) bool { return a < b }).Lift().With(recording)(context.Background(), 2, 1)
+		Expect(records).To(Equal([]invocationRecord{
+			{Kind: funk.KindFunc, Args: []any{1}, Result: "a"},
+			{Kind: funk.KindUnary, Args: []any{1}, Result: 2},
+			{Kind: funk.KindBiFunc, Args: []any{1, 2}, Result: 3},
+			{Kind: funk.KindSupplier, Result: 0, Err: cause},
+			{Kind: funk.KindConsumer, Args: []any{1}},
+			{Kind: funk.KindBiConsumer, Args: []any{1, "a"}},
+			{Kind: funk.KindPredicate, Args: []any{1}, Result: true},
+			{Kind: funk.KindBiPredicate, Args: []any{2, 1}, Result: false},
+		}))
+	})
+
+	It("should chain middlewares in order", func() {
+		f := funk.Constant(1).With(funk.Chain(tagging("a"), tagging("b")), tagging("c"))
+		ctx, v, err := f(context.Background())
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(v).To(Equal(1))
+		Expect(order).To(Equal([]string{"a", "b", "c"}))
+		Expect(getCtxValue(ctx)).To(Equal(3))
+	})
+
+	It("should let middlewares replace arguments and results", func() {
+		double := funk.Middleware(func(next funk.Handler) funk.Handler {
+			return func(ctx context.Context, inv *funk.Invocation) (context.Context, any, error) {
+				inv.Args[0] = inv.Args[0].(int) * 2
+				return next(ctx, inv)
+			}
+		})
+		negate := funk.Middleware(func(next funk.Handler) funk.Handler {
+			return func(ctx context.Context, inv *funk.Invocation) (context.Context, any, error) {
+				ctx, r, err := next(ctx, inv)
+				return ctx, !r.(bool), err
+			}
+		})
+		p := funk.Gt(3).Lift().With(double, negate)
+		_, v, _ := p(context.Background(), 2)
+		Expect(v).To(BeFalse())
+	})
+
+	It("should return zero values if middlewares short-circuit", func() {
+		cause := errors.New("rejected")
+		reject := funk.Middleware(func(next funk.Handler) funk.Handler {
+			return func(ctx context.Context, inv *funk.Invocation) (context.Context, any, error) {
+				return ctx, nil, cause
+			}
+		})
+		_, v, err := funk.Constant("a").With(reject)(context.Background())
+		Expect(err).To(Equal(cause))
+		Expect(v).To(BeEmpty())
+	})
+})
@@ -0,0 +1,211 @@
+package funk
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// Estimate represents the estimated cost and selectivity of a predicate.
+type Estimate struct {
+	// Cost is the expected cost of an evaluation, in nanoseconds if it's learned from runtime statistics.
+	Cost float64
+	// Selectivity is the probability that the predicate evaluates to true, in the range [0, 1].
+	Selectivity float64
+}
+
+// priorWeight is the number of evaluations the prior estimate of learned statistics is worth.
+const priorWeight = 10
+
+// PredicateStats represents the runtime statistics of a predicate, it's safe for concurrent use.
+type PredicateStats struct {
+	prior Estimate
+	calls atomic.Int64
+	trues atomic.Int64
+	nanos atomic.Int64
+}
+
+// Calls returns the number of recorded evaluations.
+func (s *PredicateStats) Calls() int64 {
+	return s.calls.Load()
+}
+
+// Estimate returns the estimate learned from the recorded evaluations, it's smoothed by the prior estimate so that
+// it tends to the prior estimate when there are few evaluations.
+func (s *PredicateStats) Estimate() Estimate {
+	calls := float64(s.calls.Load())
+	return Estimate{
+		Cost:        (s.prior.Cost*priorWeight + float64(s.nanos.Load())) / (priorWeight + calls),
+		Selectivity: (s.prior.Selectivity*priorWeight + float64(s.trues.Load())) / (priorWeight + calls),
+	}
+}
+
+func (s *PredicateStats) record(d time.Duration, v bool) {
+	s.calls.Add(1)
+	s.nanos.Add(int64(d))
+	if v {
+		s.trues.Add(1)
+	}
+}
+
+// CostedPredicate represents a Predicate annotated with its estimate, it's reordered by OptimizedAllOf and
+// OptimizedAnyOf.
+type CostedPredicate[T any] struct {
+	predicate Predicate[T]
+	estimate  func() Estimate
+	stats     *PredicateStats
+	pinned    bool
+}
+
+// WithEstimate annotates p with a static estimate.
+func WithEstimate[T any](p Predicate[T], e Estimate) CostedPredicate[T] {
+	return CostedPredicate[T]{
+		predicate: p,
+		estimate:  func() Estimate { return e },
+	}
+}
+
+// WithStats annotates p with an estimate learned from its evaluations, starting from prior.
+// The returned PredicateStats records every evaluation of the returned predicate.
+func WithStats[T any](p Predicate[T], prior Estimate) (CostedPredicate[T], *PredicateStats) {
+	stats := &PredicateStats{prior: prior}
+	return CostedPredicate[T]{
+		predicate: func(ctx context.Context, t T) (context.Context, bool, error) {
+			start := time.Now()
+			ctx, v, err := p(ctx, t)
+			stats.record(time.Since(start), v && err == nil)
+			return ctx, v, err
+		},
+		estimate: stats.Estimate,
+		stats:    stats,
+	}, stats
+}
+
+// Pinned returns a CostedPredicate that is never reordered, it's for predicates whose side effects on the context
+// make the order significant. Other predicates are only reordered between pinned ones.
+func (c CostedPredicate[T]) Pinned() CostedPredicate[T] {
+	c.pinned = true
+	return c
+}
+
+// Predicate returns the annotated Predicate.
+func (c CostedPredicate[T]) Predicate() Predicate[T] {
+	return c.predicate
+}
+
+// Estimate returns the current estimate.
+func (c CostedPredicate[T]) Estimate() Estimate {
+	return c.estimate()
+}
+
+// reorderEvery is the number of evaluations recorded by the learned statistics of a group between reorderings.
+const reorderEvery = 64
+
+// OptimizedAllOf returns a Predicate like AllOf, except that predicates are evaluated in the order that minimizes the
+// expected cost by their estimates, that is cheap predicates that are likely false first. The order is decided when
+// the predicate is built, and decided again once the predicates annotated by WithStats have recorded some
+// evaluations since.
+func OptimizedAllOf[T any](ps ...CostedPredicate[T]) Predicate[T] {
+	return optimized("allOf", ps, allOf(len(ps)), func(e Estimate) float64 {
+		return rank(e.Cost, 1-e.Selectivity)
+	})
+}
+
+// OptimizedAnyOf returns a Predicate like AnyOf, except that predicates are evaluated in the order that minimizes the
+// expected cost by their estimates, that is cheap predicates that are likely true first. The order is decided as
+// OptimizedAllOf does.
+func OptimizedAnyOf[T any](ps ...CostedPredicate[T]) Predicate[T] {
+	return optimized("anyOf", ps, anyOf(len(ps)), func(e Estimate) float64 {
+		return rank(e.Cost, e.Selectivity)
+	})
+}
+
+// rank is the expected cost per short-circuit, evaluating predicates in ascending order of rank minimizes the
+// expected cost of independent predicates.
+func rank(cost, shortCircuit float64) float64 {
+	if shortCircuit <= 0 {
+		return math.Inf(1)
+	}
+	return cost / shortCircuit
+}
+
+// ordering is an order of the predicates of an optimized group, and the number of evaluations recorded by their
+// learned statistics when it was decided.
+type ordering[T any] struct {
+	ps      []CostedPredicate[T]
+	learned int64
+}
+
+func optimized[T any](name string, ps []CostedPredicate[T], decide decision, rankOf func(Estimate) float64) Predicate[T] {
+	var current atomic.Pointer[ordering[T]]
+	current.Store(&ordering[T]{ps: order(ps, rankOf), learned: learned(ps)})
+	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		o := current.Load()
+		if n := learned(ps); n-o.learned >= reorderEvery {
+			next := &ordering[T]{ps: order(ps, rankOf), learned: n}
+			if current.CompareAndSwap(o, next) {
+				o = next
+			}
+		}
+		operands := make([]evaluation, len(o.ps))
+		for i, p := range o.ps {
+			operands[i] = p.predicate.bind(t)
+		}
+		return combine(ctx, name, operands, decide)
+	}
+}
+
+// learned returns the number of evaluations recorded by the learned statistics of ps.
+func learned[T any](ps []CostedPredicate[T]) int64 {
+	var n int64
+	for _, p := range ps {
+		if p.stats != nil {
+			n += p.stats.Calls()
+		}
+	}
+	return n
+}
+
+// order returns ps sorted by rank between pinned predicates.
+func order[T any](ps []CostedPredicate[T], rankOf func(Estimate) float64) []CostedPredicate[T] {
+	ordered := make([]CostedPredicate[T], len(ps))
+	copy(ordered, ps)
+	ranks := make([]float64, len(ps))
+	for i, p := range ordered {
+		if !p.pinned {
+			ranks[i] = rankOf(p.estimate())
+		}
+	}
+	sortSegment := func(from, to int) {
+		sort.Stable(rankSorter[T]{ordered[from:to], ranks[from:to]})
+	}
+	from := 0
+	for i, p := range ordered {
+		if p.pinned {
+			sortSegment(from, i)
+			from = i + 1
+		}
+	}
+	sortSegment(from, len(ordered))
+	return ordered
+}
+
+type rankSorter[T any] struct {
+	ps    []CostedPredicate[T]
+	ranks []float64
+}
+
+func (s rankSorter[T]) Len() int {
+	return len(s.ps)
+}
+
+func (s rankSorter[T]) Less(i, j int) bool {
+	return s.ranks[i] < s.ranks[j]
+}
+
+func (s rankSorter[T]) Swap(i, j int) {
+	s.ps[i], s.ps[j] = s.ps[j], s.ps[i]
+	s.ranks[i], s.ranks[j] = s.ranks[j], s.ranks[i]
+}
@@ -0,0 +1,118 @@
+package funk_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Optimizing predicates", func() {
+	var evaluated []string
+	recorded := func(name string, v bool) funk.Predicate[int] {
+		return func(ctx context.Context, i int) (context.Context, bool, error) {
+			evaluated = append(evaluated, name)
+			return ctx, v, nil
+		}
+	}
+	BeforeEach(func() {
+		evaluated = nil
+	})
+
+	It("should evaluate cheap and likely false predicates first in AllOf", func() {
+		p := funk.OptimizedAllOf(
+			funk.WithEstimate(recorded("remote", true), funk.Estimate{Cost: 1000, Selectivity: 0.5}),
+			funk.WithEstimate(recorded("selective", true), funk.Estimate{Cost: 10, Selectivity: 0.1}),
+			funk.WithEstimate(recorded("cheap", true), funk.Estimate{Cost: 1, Selectivity: 0.5}),
+			funk.WithEstimate(recorded("always", true), funk.Estimate{Cost: 1, Selectivity: 1}),
+		)
+		_, v, err := p(context.Background(), 0)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(v).To(BeTrue())
+		Expect(evaluated).To(Equal([]string{"cheap", "selective", "remote", "always"}))
+	})
+
+	It("should evaluate cheap and likely true predicates first in AnyOf", func() {
+		p := funk.OptimizedAnyOf(
+			funk.WithEstimate(recorded("remote", false), funk.Estimate{Cost: 1000, Selectivity: 0.9}),
+			funk.WithEstimate(recorded("never", false), funk.Estimate{Cost: 1, Selectivity: 0}),
+			funk.WithEstimate(recorded("cheap", true), funk.Estimate{Cost: 1, Selectivity: 0.5}),
+		)
+		_, v, _ := p(context.Background(), 0)
+		Expect(v).To(BeTrue())
+		Expect(evaluated).To(Equal([]string{"cheap"}))
+	})
+
+	It("should not reorder pinned predicates", func() {
+		p := funk.OptimizedAllOf(
+			funk.WithEstimate(recorded("a", true), funk.Estimate{Cost: 3, Selectivity: 0.5}),
+			funk.WithEstimate(recorded("b", true), funk.Estimate{Cost: 2, Selectivity: 0.5}),
+			funk.WithEstimate(recorded("pinned", true), funk.Estimate{Cost: 100, Selectivity: 0.5}).Pinned(),
+			funk.WithEstimate(recorded("c", true), funk.Estimate{Cost: 5, Selectivity: 0.5}),
+			funk.WithEstimate(recorded("d", true), funk.Estimate{Cost: 4, Selectivity: 0.5}),
+		)
+		_, _, _ = p(context.Background(), 0)
+		Expect(evaluated).To(Equal([]string{"b", "a", "pinned", "d", "c"}))
+	})
+
+	It("should learn estimates from runtime statistics", func() {
+		rare, stats := funk.WithStats(funk.Predicate[int](func(ctx context.Context, i int) (context.Context, bool, error) {
+			evaluated = append(evaluated, "learned")
+			return ctx, i%10 == 0, nil
+		}), funk.Estimate{Cost: 1, Selectivity: 1})
+		p := funk.OptimizedAllOf(
+			funk.WithEstimate(recorded("static", true), funk.Estimate{Cost: 1e9, Selectivity: 0.5}),
+			rare,
+		)
+		_, _, _ = p(context.Background(), 1)
+		Expect(evaluated).To(Equal([]string{"static", "learned"}))
+
+		for i := 1; i < 1000; i++ {
+			_, _, _ = rare.Predicate()(context.Background(), i)
+		}
+		Expect(stats.Calls()).To(Equal(int64(1000)))
+		Expect(rare.Estimate().Selectivity).To(BeNumerically("~", 0.1, 0.02))
+
+		evaluated = nil
+		_, _, _ = p(context.Background(), 1)
+		Expect(evaluated).To(Equal([]string{"learned"}))
+	})
+
+	It("should keep the order until enough evaluations are recorded", func() {
+		never, _ := funk.WithStats(recorded("learned", false), funk.Estimate{Cost: 1, Selectivity: 1})
+		p := funk.OptimizedAllOf(
+			funk.WithEstimate(recorded("static", true), funk.Estimate{Cost: 1e3, Selectivity: 0.5}),
+			never,
+		)
+		for i := 0; i < 10; i++ {
+			_, _, _ = never.Predicate()(context.Background(), i)
+		}
+		evaluated = nil
+		_, _, _ = p(context.Background(), 0)
+		Expect(evaluated).To(Equal([]string{"static", "learned"}))
+
+		for i := 0; i < 100; i++ {
+			_, _, _ = never.Predicate()(context.Background(), i)
+		}
+		evaluated = nil
+		_, _, _ = p(context.Background(), 0)
+		Expect(evaluated).To(Equal([]string{"learned"}))
+	})
+
+	It("should thread context and be explainable", func() {
+		inc := func(v bool) funk.Predicate[int] {
+			return func(ctx context.Context, i int) (context.Context, bool, error) {
+				return incCtxValue(ctx), v, nil
+			}
+		}
+		p := funk.OptimizedAllOf(
+			funk.WithEstimate(inc(true).Named("expensive"), funk.Estimate{Cost: 10, Selectivity: 0.5}),
+			funk.WithEstimate(inc(false).Named("cheap"), funk.Estimate{Cost: 1, Selectivity: 0.5}),
+		)
+		ctx, e := p.Explain(context.Background(), 0)
+		Expect(getCtxValue(ctx)).To(Equal(1))
+		Expect(e.String()).To(Equal("allOf: false\n  cheap: false\n  <anonymous>: short-circuited\n"))
+	})
+})
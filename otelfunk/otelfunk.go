@@ -0,0 +1,43 @@
+// Package otelfunk adapts OpenTelemetry tracers to funk.Tracer.
+package otelfunk
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+// Tracer returns a funk.Tracer that opens spans with t and opts. The spans of failed calls record the error and have
+// the error status.
+func Tracer(t trace.Tracer, opts ...trace.SpanStartOption) funk.Tracer {
+	return tracer{t: t, opts: opts}
+}
+
+type tracer struct {
+	t    trace.Tracer
+	opts []trace.SpanStartOption
+}
+
+func (t tracer) Start(ctx context.Context, name string) (context.Context, funk.Span) {
+	ctx, s := t.t.Start(ctx, name, t.opts...)
+	return ctx, span{s: s}
+}
+
+func (t tracer) Resume(ctx, from context.Context) context.Context {
+	return trace.ContextWithSpan(ctx, trace.SpanFromContext(from))
+}
+
+type span struct {
+	s trace.Span
+}
+
+func (s span) End(err error) {
+	if err != nil {
+		s.s.RecordError(err)
+		s.s.SetStatus(codes.Error, err.Error())
+	}
+	s.s.End()
+}
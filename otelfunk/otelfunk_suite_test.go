@@ -0,0 +1,13 @@
+package otelfunk_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestOtelfunk(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Otelfunk Suite")
+}
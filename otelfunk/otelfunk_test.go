@@ -0,0 +1,78 @@
+package otelfunk_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	funk "github.com/hongcankun/gofunk"
+	"github.com/hongcankun/gofunk/otelfunk"
+)
+
+type recordingSpan struct {
+	noop.Span
+	name   string
+	parent *recordingSpan
+	status codes.Code
+	errs   []error
+	ended  bool
+}
+
+func (s *recordingSpan) RecordError(err error, _ ...trace.EventOption) {
+	s.errs = append(s.errs, err)
+}
+
+func (s *recordingSpan) SetStatus(code codes.Code, _ string) {
+	s.status = code
+}
+
+func (s *recordingSpan) End(...trace.SpanEndOption) {
+	s.ended = true
+}
+
+type recordingTracer struct {
+	noop.Tracer
+	spans []*recordingSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+	s := &recordingSpan{name: name}
+	s.parent, _ = trace.SpanFromContext(ctx).(*recordingSpan)
+	t.spans = append(t.spans, s)
+	return trace.ContextWithSpan(ctx, s), s
+}
+
+var _ = Describe("Tracer", func() {
+	It("should open OpenTelemetry spans as a tree", func() {
+		rt := &recordingTracer{}
+		t := otelfunk.Tracer(rt)
+		cause := errors.New("boom")
+		inner := funk.Unary[int](func(ctx context.Context, i int) (context.Context, int, error) {
+			return ctx, i + 1, nil
+		})
+		failing := funk.Unary[int](func(ctx context.Context, i int) (context.Context, int, error) {
+			return ctx, i, cause
+		})
+		u := inner.With(funk.Traced(t, "a")).Then(failing.With(funk.Traced(t, "b"))).With(funk.Traced(t, "root"))
+		ctx, _, err := u(context.Background(), 1)
+		Expect(err).To(Equal(cause))
+
+		Expect(rt.spans).To(HaveLen(3))
+		root, a, b := rt.spans[0], rt.spans[1], rt.spans[2]
+		Expect(root.name).To(Equal("root"))
+		Expect(a.parent).To(BeIdenticalTo(root))
+		Expect(b.parent).To(BeIdenticalTo(root))
+		Expect(b.errs).To(Equal([]error{cause}))
+		Expect(b.status).To(Equal(codes.Error))
+		Expect(a.status).To(Equal(codes.Unset))
+		Expect(root.ended && a.ended && b.ended).To(BeTrue())
+		Expect(trace.SpanFromContext(ctx).SpanContext().IsValid()).To(BeFalse())
+		_, isRecording := trace.SpanFromContext(ctx).(*recordingSpan)
+		Expect(isRecording).To(BeFalse())
+	})
+})
@@ -52,9 +52,35 @@ func (p PurePredicate[T]) Must() PureMustPredicate[T] {
 	}
 }
 
+// Lift returns a Predicate that passes the context through and never returns error.
+func (p MustPredicate[T]) Lift() Predicate[T] {
+	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		ctx, v := p(ctx, t)
+		return ctx, v, nil
+	}
+}
+
+// Lift returns a Predicate that passes the context through untouched.
+func (p PurePredicate[T]) Lift() Predicate[T] {
+	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		v, err := p(t)
+		return ctx, v, err
+	}
+}
+
+// Lift returns a Predicate that passes the context through untouched and never returns error.
+func (p PureMustPredicate[T]) Lift() Predicate[T] {
+	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		return ctx, p(t), nil
+	}
+}
+
 // And returns a composed Predicate that represents a short-circuiting logical AND of this predicate and another.
 func (p Predicate[T]) And(other Predicate[T]) Predicate[T] {
 	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		if explainerFrom(ctx) != nil {
+			return and(ctx, p.bind(t), other.bind(t))
+		}
 		ctx, v, err := p(ctx, t)
 		if v == false || err != nil {
 			return ctx, v, err
@@ -66,6 +92,9 @@ func (p Predicate[T]) And(other Predicate[T]) Predicate[T] {
 // Or returns a composed Predicate that represents a short-circuiting logical OR of this predicate and another.
 func (p Predicate[T]) Or(other Predicate[T]) Predicate[T] {
 	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		if explainerFrom(ctx) != nil {
+			return or(ctx, p.bind(t), other.bind(t))
+		}
 		ctx, v, err := p(ctx, t)
 		if v == true || err != nil {
 			return ctx, v, err
@@ -77,6 +106,9 @@ func (p Predicate[T]) Or(other Predicate[T]) Predicate[T] {
 // Not returns a Predicate that represents the logical negation of this predicate.
 func (p Predicate[T]) Not() Predicate[T] {
 	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		if explainerFrom(ctx) != nil {
+			return not(ctx, p.bind(t))
+		}
 		ctx, v, err := p(ctx, t)
 		return ctx, !v, err
 	}
@@ -84,7 +116,12 @@ func (p Predicate[T]) Not() Predicate[T] {
 
 // And returns a composed MustPredicate that represents a short-circuiting logical AND of this predicate and another.
 func (p MustPredicate[T]) And(other MustPredicate[T]) MustPredicate[T] {
+	explained := p.Lift().And(other.Lift())
 	return func(ctx context.Context, t T) (context.Context, bool) {
+		if explainerFrom(ctx) != nil {
+			ctx, v, _ := explained(ctx, t)
+			return ctx, v
+		}
 		ctx, v := p(ctx, t)
 		if v == false {
 			return ctx, v
@@ -95,7 +132,12 @@ func (p MustPredicate[T]) And(other MustPredicate[T]) MustPredicate[T] {
 
 // Or returns a composed MustPredicate that represents a short-circuiting logical OR of this predicate and another.
 func (p MustPredicate[T]) Or(other MustPredicate[T]) MustPredicate[T] {
+	explained := p.Lift().Or(other.Lift())
 	return func(ctx context.Context, t T) (context.Context, bool) {
+		if explainerFrom(ctx) != nil {
+			ctx, v, _ := explained(ctx, t)
+			return ctx, v
+		}
 		ctx, v := p(ctx, t)
 		if v == true {
 			return ctx, v
@@ -106,7 +148,12 @@ func (p MustPredicate[T]) Or(other MustPredicate[T]) MustPredicate[T] {
 
 // Not returns a MustPredicate that represents the logical negation of this predicate.
 func (p MustPredicate[T]) Not() MustPredicate[T] {
+	explained := p.Lift().Not()
 	return func(ctx context.Context, t T) (context.Context, bool) {
+		if explainerFrom(ctx) != nil {
+			ctx, v, _ := explained(ctx, t)
+			return ctx, v
+		}
 		ctx, v := p(ctx, t)
 		return ctx, !v
 	}
@@ -213,9 +260,35 @@ func (p PureBiPredicate[T, U]) Must() PureMustBiPredicate[T, U] {
 	}
 }
 
+// Lift returns a BiPredicate that passes the context through and never returns error.
+func (p MustBiPredicate[T, U]) Lift() BiPredicate[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, bool, error) {
+		ctx, v := p(ctx, t, u)
+		return ctx, v, nil
+	}
+}
+
+// Lift returns a BiPredicate that passes the context through untouched.
+func (p PureBiPredicate[T, U]) Lift() BiPredicate[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, bool, error) {
+		v, err := p(t, u)
+		return ctx, v, err
+	}
+}
+
+// Lift returns a BiPredicate that passes the context through untouched and never returns error.
+func (p PureMustBiPredicate[T, U]) Lift() BiPredicate[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, bool, error) {
+		return ctx, p(t, u), nil
+	}
+}
+
 // And returns a composed predicate that represents a short-circuiting logical AND of this predicate and another.
 func (p BiPredicate[T, U]) And(other BiPredicate[T, U]) BiPredicate[T, U] {
 	return func(ctx context.Context, t T, u U) (context.Context, bool, error) {
+		if explainerFrom(ctx) != nil {
+			return and(ctx, p.bind(t, u), other.bind(t, u))
+		}
 		ctx, v, err := p(ctx, t, u)
 		if v == false || err != nil {
 			return ctx, v, err
@@ -227,6 +300,9 @@ func (p BiPredicate[T, U]) And(other BiPredicate[T, U]) BiPredicate[T, U] {
 // Or returns a composed predicate that represents a short-circuiting logical OR of this predicate and another.
 func (p BiPredicate[T, U]) Or(other BiPredicate[T, U]) BiPredicate[T, U] {
 	return func(ctx context.Context, t T, u U) (context.Context, bool, error) {
+		if explainerFrom(ctx) != nil {
+			return or(ctx, p.bind(t, u), other.bind(t, u))
+		}
 		ctx, v, err := p(ctx, t, u)
 		if v == true || err != nil {
 			return ctx, v, err
@@ -238,6 +314,9 @@ func (p BiPredicate[T, U]) Or(other BiPredicate[T, U]) BiPredicate[T, U] {
 // Not returns a predicate that represents the logical negation of this predicate.
 func (p BiPredicate[T, U]) Not() BiPredicate[T, U] {
 	return func(ctx context.Context, t T, u U) (context.Context, bool, error) {
+		if explainerFrom(ctx) != nil {
+			return not(ctx, p.bind(t, u))
+		}
 		ctx, v, err := p(ctx, t, u)
 		return ctx, !v, err
 	}
@@ -245,7 +324,12 @@ func (p BiPredicate[T, U]) Not() BiPredicate[T, U] {
 
 // And returns a composed MustBiPredicate that represents a short-circuiting logical AND of this predicate and another.
 func (p MustBiPredicate[T, U]) And(other MustBiPredicate[T, U]) MustBiPredicate[T, U] {
+	explained := p.Lift().And(other.Lift())
 	return func(ctx context.Context, t T, u U) (context.Context, bool) {
+		if explainerFrom(ctx) != nil {
+			ctx, v, _ := explained(ctx, t, u)
+			return ctx, v
+		}
 		ctx, v := p(ctx, t, u)
 		if v == false {
 			return ctx, v
@@ -256,7 +340,12 @@ func (p MustBiPredicate[T, U]) And(other MustBiPredicate[T, U]) MustBiPredicate[
 
 // Or returns a composed MustBiPredicate that represents a short-circuiting logical OR of this predicate and another.
 func (p MustBiPredicate[T, U]) Or(other MustBiPredicate[T, U]) MustBiPredicate[T, U] {
+	explained := p.Lift().Or(other.Lift())
 	return func(ctx context.Context, t T, u U) (context.Context, bool) {
+		if explainerFrom(ctx) != nil {
+			ctx, v, _ := explained(ctx, t, u)
+			return ctx, v
+		}
 		ctx, v := p(ctx, t, u)
 		if v == true {
 			return ctx, v
@@ -267,7 +356,12 @@ func (p MustBiPredicate[T, U]) Or(other MustBiPredicate[T, U]) MustBiPredicate[T
 
 // Not returns a MustBiPredicate that represents the logical negation of this predicate.
 func (p MustBiPredicate[T, U]) Not() MustBiPredicate[T, U] {
+	explained := p.Lift().Not()
 	return func(ctx context.Context, t T, u U) (context.Context, bool) {
+		if explainerFrom(ctx) != nil {
+			ctx, v, _ := explained(ctx, t, u)
+			return ctx, v
+		}
 		ctx, v := p(ctx, t, u)
 		return ctx, !v
 	}
@@ -323,3 +417,47 @@ func (p PureMustBiPredicate[T, U]) Not() PureMustBiPredicate[T, U] {
 		return !p(t, u)
 	}
 }
+
+// evaluation represents a predicate whose arguments are already bound.
+type evaluation func(context.Context) (context.Context, bool, error)
+
+func (p Predicate[T]) bind(t T) evaluation {
+	return func(ctx context.Context) (context.Context, bool, error) {
+		return p(ctx, t)
+	}
+}
+
+func (p BiPredicate[T, U]) bind(t T, u U) evaluation {
+	return func(ctx context.Context) (context.Context, bool, error) {
+		return p(ctx, t, u)
+	}
+}
+
+func and(ctx context.Context, left, right evaluation) (context.Context, bool, error) {
+	return explainNode(ctx, "and", func(ctx context.Context) (context.Context, bool, error) {
+		ctx, v, err := explainOperand(ctx, left)
+		if v == false || err != nil {
+			explainSkipped(ctx)
+			return ctx, v, err
+		}
+		return explainOperand(ctx, right)
+	})
+}
+
+func or(ctx context.Context, left, right evaluation) (context.Context, bool, error) {
+	return explainNode(ctx, "or", func(ctx context.Context) (context.Context, bool, error) {
+		ctx, v, err := explainOperand(ctx, left)
+		if v == true || err != nil {
+			explainSkipped(ctx)
+			return ctx, v, err
+		}
+		return explainOperand(ctx, right)
+	})
+}
+
+func not(ctx context.Context, operand evaluation) (context.Context, bool, error) {
+	return explainNode(ctx, "not", func(ctx context.Context) (context.Context, bool, error) {
+		ctx, v, err := explainOperand(ctx, operand)
+		return ctx, !v, err
+	})
+}
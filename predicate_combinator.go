@@ -0,0 +1,396 @@
+package funk
+
+import "context"
+
+// decision decides the result of a variadic predicate combinator from the number of predicates evaluated to true and
+// false so far. It reports done once the result can't be changed by the remaining predicates.
+type decision func(trues, falses int) (v bool, done bool)
+
+// combine evaluates operands in order, threading the context through each evaluated operand, until decide reports
+// done or any operand returns error, in which case the value of that operand is returned with its error as And and
+// Or do. The operands that are not evaluated are explained as short-circuited.
+func combine(ctx context.Context, name string, operands []evaluation, decide decision) (context.Context, bool, error) {
+	return explainNode(ctx, name, func(ctx context.Context) (context.Context, bool, error) {
+		trues, falses := 0, 0
+		for {
+			v, done := decide(trues, falses)
+			if done {
+				for i := trues + falses; i < len(operands); i++ {
+					explainSkipped(ctx)
+				}
+				return ctx, v, nil
+			}
+			ctx2, v, err := explainOperand(ctx, operands[trues+falses])
+			ctx = ctx2
+			if err != nil {
+				for i := trues + falses + 1; i < len(operands); i++ {
+					explainSkipped(ctx)
+				}
+				return ctx, v, err
+			}
+			if v {
+				trues++
+			} else {
+				falses++
+			}
+		}
+	})
+}
+
+func allOf(n int) decision {
+	return func(trues, falses int) (bool, bool) {
+		return falses == 0, falses > 0 || trues == n
+	}
+}
+
+func anyOf(n int) decision {
+	return func(trues, falses int) (bool, bool) {
+		return trues > 0, trues > 0 || falses == n
+	}
+}
+
+func noneOf(n int) decision {
+	return func(trues, falses int) (bool, bool) {
+		return trues == 0, trues > 0 || falses == n
+	}
+}
+
+func xor(n int) decision {
+	return func(trues, falses int) (bool, bool) {
+		return trues%2 == 1, trues+falses == n
+	}
+}
+
+func atLeastN(k, n int) decision {
+	return func(trues, falses int) (bool, bool) {
+		remaining := n - trues - falses
+		return trues >= k, trues >= k || trues+remaining < k
+	}
+}
+
+func exactlyN(k, n int) decision {
+	return func(trues, falses int) (bool, bool) {
+		remaining := n - trues - falses
+		return trues == k && remaining == 0, trues > k || trues+remaining < k || remaining == 0
+	}
+}
+
+func combinePredicates[T any](name string, ps []Predicate[T], decide decision) Predicate[T] {
+	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		operands := make([]evaluation, len(ps))
+		for i, p := range ps {
+			operands[i] = p.bind(t)
+		}
+		return combine(ctx, name, operands, decide)
+	}
+}
+
+func combineBiPredicates[T, U any](name string, ps []BiPredicate[T, U], decide decision) BiPredicate[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, bool, error) {
+		operands := make([]evaluation, len(ps))
+		for i, p := range ps {
+			operands[i] = p.bind(t, u)
+		}
+		return combine(ctx, name, operands, decide)
+	}
+}
+
+func liftMustPredicates[T any](ps []MustPredicate[T]) []Predicate[T] {
+	lifted := make([]Predicate[T], len(ps))
+	for i, p := range ps {
+		lifted[i] = p.Lift()
+	}
+	return lifted
+}
+
+func liftPurePredicates[T any](ps []PurePredicate[T]) []Predicate[T] {
+	lifted := make([]Predicate[T], len(ps))
+	for i, p := range ps {
+		lifted[i] = p.Lift()
+	}
+	return lifted
+}
+
+func liftPureMustPredicates[T any](ps []PureMustPredicate[T]) []Predicate[T] {
+	lifted := make([]Predicate[T], len(ps))
+	for i, p := range ps {
+		lifted[i] = p.Lift()
+	}
+	return lifted
+}
+
+func liftMustBiPredicates[T, U any](ps []MustBiPredicate[T, U]) []BiPredicate[T, U] {
+	lifted := make([]BiPredicate[T, U], len(ps))
+	for i, p := range ps {
+		lifted[i] = p.Lift()
+	}
+	return lifted
+}
+
+func liftPureBiPredicates[T, U any](ps []PureBiPredicate[T, U]) []BiPredicate[T, U] {
+	lifted := make([]BiPredicate[T, U], len(ps))
+	for i, p := range ps {
+		lifted[i] = p.Lift()
+	}
+	return lifted
+}
+
+func liftPureMustBiPredicates[T, U any](ps []PureMustBiPredicate[T, U]) []BiPredicate[T, U] {
+	lifted := make([]BiPredicate[T, U], len(ps))
+	for i, p := range ps {
+		lifted[i] = p.Lift()
+	}
+	return lifted
+}
+
+// AllOf returns a composed Predicate that represents a short-circuiting logical AND of all predicates.
+// It evaluates to true if no predicate is given.
+func AllOf[T any](ps ...Predicate[T]) Predicate[T] {
+	return combinePredicates("allOf", ps, allOf(len(ps)))
+}
+
+// AnyOf returns a composed Predicate that represents a short-circuiting logical OR of all predicates.
+// It evaluates to false if no predicate is given.
+func AnyOf[T any](ps ...Predicate[T]) Predicate[T] {
+	return combinePredicates("anyOf", ps, anyOf(len(ps)))
+}
+
+// NoneOf returns a composed Predicate that evaluates to true if none of the predicates evaluates to true.
+// It short-circuits on the first predicate that evaluates to true.
+func NoneOf[T any](ps ...Predicate[T]) Predicate[T] {
+	return combinePredicates("noneOf", ps, noneOf(len(ps)))
+}
+
+// Xor returns a composed Predicate that evaluates to true if an odd number of predicates evaluates to true.
+// It always evaluates all predicates unless one of them returns error.
+func Xor[T any](ps ...Predicate[T]) Predicate[T] {
+	return combinePredicates("xor", ps, xor(len(ps)))
+}
+
+// AtLeastN returns a composed Predicate that evaluates to true if at least n predicates evaluate to true.
+// It short-circuits as soon as the result is known.
+func AtLeastN[T any](n int, ps ...Predicate[T]) Predicate[T] {
+	return combinePredicates("atLeastN", ps, atLeastN(n, len(ps)))
+}
+
+// ExactlyN returns a composed Predicate that evaluates to true if exactly n predicates evaluate to true.
+// It short-circuits as soon as the result is known.
+func ExactlyN[T any](n int, ps ...Predicate[T]) Predicate[T] {
+	return combinePredicates("exactlyN", ps, exactlyN(n, len(ps)))
+}
+
+// MustAllOf is AllOf for MustPredicate.
+func MustAllOf[T any](ps ...MustPredicate[T]) MustPredicate[T] {
+	return AllOf(liftMustPredicates(ps)...).Must()
+}
+
+// MustAnyOf is AnyOf for MustPredicate.
+func MustAnyOf[T any](ps ...MustPredicate[T]) MustPredicate[T] {
+	return AnyOf(liftMustPredicates(ps)...).Must()
+}
+
+// MustNoneOf is NoneOf for MustPredicate.
+func MustNoneOf[T any](ps ...MustPredicate[T]) MustPredicate[T] {
+	return NoneOf(liftMustPredicates(ps)...).Must()
+}
+
+// MustXor is Xor for MustPredicate.
+func MustXor[T any](ps ...MustPredicate[T]) MustPredicate[T] {
+	return Xor(liftMustPredicates(ps)...).Must()
+}
+
+// MustAtLeastN is AtLeastN for MustPredicate.
+func MustAtLeastN[T any](n int, ps ...MustPredicate[T]) MustPredicate[T] {
+	return AtLeastN(n, liftMustPredicates(ps)...).Must()
+}
+
+// MustExactlyN is ExactlyN for MustPredicate.
+func MustExactlyN[T any](n int, ps ...MustPredicate[T]) MustPredicate[T] {
+	return ExactlyN(n, liftMustPredicates(ps)...).Must()
+}
+
+// PureAllOf is AllOf for PurePredicate.
+func PureAllOf[T any](ps ...PurePredicate[T]) PurePredicate[T] {
+	return AllOf(liftPurePredicates(ps)...).Pure()
+}
+
+// PureAnyOf is AnyOf for PurePredicate.
+func PureAnyOf[T any](ps ...PurePredicate[T]) PurePredicate[T] {
+	return AnyOf(liftPurePredicates(ps)...).Pure()
+}
+
+// PureNoneOf is NoneOf for PurePredicate.
+func PureNoneOf[T any](ps ...PurePredicate[T]) PurePredicate[T] {
+	return NoneOf(liftPurePredicates(ps)...).Pure()
+}
+
+// PureXor is Xor for PurePredicate.
+func PureXor[T any](ps ...PurePredicate[T]) PurePredicate[T] {
+	return Xor(liftPurePredicates(ps)...).Pure()
+}
+
+// PureAtLeastN is AtLeastN for PurePredicate.
+func PureAtLeastN[T any](n int, ps ...PurePredicate[T]) PurePredicate[T] {
+	return AtLeastN(n, liftPurePredicates(ps)...).Pure()
+}
+
+// PureExactlyN is ExactlyN for PurePredicate.
+func PureExactlyN[T any](n int, ps ...PurePredicate[T]) PurePredicate[T] {
+	return ExactlyN(n, liftPurePredicates(ps)...).Pure()
+}
+
+// PureMustAllOf is AllOf for PureMustPredicate.
+func PureMustAllOf[T any](ps ...PureMustPredicate[T]) PureMustPredicate[T] {
+	return AllOf(liftPureMustPredicates(ps)...).Must().Pure()
+}
+
+// PureMustAnyOf is AnyOf for PureMustPredicate.
+func PureMustAnyOf[T any](ps ...PureMustPredicate[T]) PureMustPredicate[T] {
+	return AnyOf(liftPureMustPredicates(ps)...).Must().Pure()
+}
+
+// PureMustNoneOf is NoneOf for PureMustPredicate.
+func PureMustNoneOf[T any](ps ...PureMustPredicate[T]) PureMustPredicate[T] {
+	return NoneOf(liftPureMustPredicates(ps)...).Must().Pure()
+}
+
+// PureMustXor is Xor for PureMustPredicate.
+func PureMustXor[T any](ps ...PureMustPredicate[T]) PureMustPredicate[T] {
+	return Xor(liftPureMustPredicates(ps)...).Must().Pure()
+}
+
+// PureMustAtLeastN is AtLeastN for PureMustPredicate.
+func PureMustAtLeastN[T any](n int, ps ...PureMustPredicate[T]) PureMustPredicate[T] {
+	return AtLeastN(n, liftPureMustPredicates(ps)...).Must().Pure()
+}
+
+// PureMustExactlyN is ExactlyN for PureMustPredicate.
+func PureMustExactlyN[T any](n int, ps ...PureMustPredicate[T]) PureMustPredicate[T] {
+	return ExactlyN(n, liftPureMustPredicates(ps)...).Must().Pure()
+}
+
+// BiAllOf returns a composed BiPredicate that represents a short-circuiting logical AND of all predicates.
+// It evaluates to true if no predicate is given.
+func BiAllOf[T, U any](ps ...BiPredicate[T, U]) BiPredicate[T, U] {
+	return combineBiPredicates("allOf", ps, allOf(len(ps)))
+}
+
+// BiAnyOf returns a composed BiPredicate that represents a short-circuiting logical OR of all predicates.
+// It evaluates to false if no predicate is given.
+func BiAnyOf[T, U any](ps ...BiPredicate[T, U]) BiPredicate[T, U] {
+	return combineBiPredicates("anyOf", ps, anyOf(len(ps)))
+}
+
+// BiNoneOf returns a composed BiPredicate that evaluates to true if none of the predicates evaluates to true.
+// It short-circuits on the first predicate that evaluates to true.
+func BiNoneOf[T, U any](ps ...BiPredicate[T, U]) BiPredicate[T, U] {
+	return combineBiPredicates("noneOf", ps, noneOf(len(ps)))
+}
+
+// BiXor returns a composed BiPredicate that evaluates to true if an odd number of predicates evaluates to true.
+// It always evaluates all predicates unless one of them returns error.
+func BiXor[T, U any](ps ...BiPredicate[T, U]) BiPredicate[T, U] {
+	return combineBiPredicates("xor", ps, xor(len(ps)))
+}
+
+// BiAtLeastN returns a composed BiPredicate that evaluates to true if at least n predicates evaluate to true.
+// It short-circuits as soon as the result is known.
+func BiAtLeastN[T, U any](n int, ps ...BiPredicate[T, U]) BiPredicate[T, U] {
+	return combineBiPredicates("atLeastN", ps, atLeastN(n, len(ps)))
+}
+
+// BiExactlyN returns a composed BiPredicate that evaluates to true if exactly n predicates evaluate to true.
+// It short-circuits as soon as the result is known.
+func BiExactlyN[T, U any](n int, ps ...BiPredicate[T, U]) BiPredicate[T, U] {
+	return combineBiPredicates("exactlyN", ps, exactlyN(n, len(ps)))
+}
+
+// MustBiAllOf is BiAllOf for MustBiPredicate.
+func MustBiAllOf[T, U any](ps ...MustBiPredicate[T, U]) MustBiPredicate[T, U] {
+	return BiAllOf(liftMustBiPredicates(ps)...).Must()
+}
+
+// MustBiAnyOf is BiAnyOf for MustBiPredicate.
+func MustBiAnyOf[T, U any](ps ...MustBiPredicate[T, U]) MustBiPredicate[T, U] {
+	return BiAnyOf(liftMustBiPredicates(ps)...).Must()
+}
+
+// MustBiNoneOf is BiNoneOf for MustBiPredicate.
+func MustBiNoneOf[T, U any](ps ...MustBiPredicate[T, U]) MustBiPredicate[T, U] {
+	return BiNoneOf(liftMustBiPredicates(ps)...).Must()
+}
+
+// MustBiXor is BiXor for MustBiPredicate.
+func MustBiXor[T, U any](ps ...MustBiPredicate[T, U]) MustBiPredicate[T, U] {
+	return BiXor(liftMustBiPredicates(ps)...).Must()
+}
+
+// MustBiAtLeastN is BiAtLeastN for MustBiPredicate.
+func MustBiAtLeastN[T, U any](n int, ps ...MustBiPredicate[T, U]) MustBiPredicate[T, U] {
+	return BiAtLeastN(n, liftMustBiPredicates(ps)...).Must()
+}
+
+// MustBiExactlyN is BiExactlyN for MustBiPredicate.
+func MustBiExactlyN[T, U any](n int, ps ...MustBiPredicate[T, U]) MustBiPredicate[T, U] {
+	return BiExactlyN(n, liftMustBiPredicates(ps)...).Must()
+}
+
+// PureBiAllOf is BiAllOf for PureBiPredicate.
+func PureBiAllOf[T, U any](ps ...PureBiPredicate[T, U]) PureBiPredicate[T, U] {
+	return BiAllOf(liftPureBiPredicates(ps)...).Pure()
+}
+
+// PureBiAnyOf is BiAnyOf for PureBiPredicate.
+func PureBiAnyOf[T, U any](ps ...PureBiPredicate[T, U]) PureBiPredicate[T, U] {
+	return BiAnyOf(liftPureBiPredicates(ps)...).Pure()
+}
+
+// PureBiNoneOf is BiNoneOf for PureBiPredicate.
+func PureBiNoneOf[T, U any](ps ...PureBiPredicate[T, U]) PureBiPredicate[T, U] {
+	return BiNoneOf(liftPureBiPredicates(ps)...).Pure()
+}
+
+// PureBiXor is BiXor for PureBiPredicate.
+func PureBiXor[T, U any](ps ...PureBiPredicate[T, U]) PureBiPredicate[T, U] {
+	return BiXor(liftPureBiPredicates(ps)...).Pure()
+}
+
+// PureBiAtLeastN is BiAtLeastN for PureBiPredicate.
+func PureBiAtLeastN[T, U any](n int, ps ...PureBiPredicate[T, U]) PureBiPredicate[T, U] {
+	return BiAtLeastN(n, liftPureBiPredicates(ps)...).Pure()
+}
+
+// PureBiExactlyN is BiExactlyN for PureBiPredicate.
+func PureBiExactlyN[T, U any](n int, ps ...PureBiPredicate[T, U]) PureBiPredicate[T, U] {
+	return BiExactlyN(n, liftPureBiPredicates(ps)...).Pure()
+}
+
+// PureMustBiAllOf is BiAllOf for PureMustBiPredicate.
+func PureMustBiAllOf[T, U any](ps ...PureMustBiPredicate[T, U]) PureMustBiPredicate[T, U] {
+	return BiAllOf(liftPureMustBiPredicates(ps)...).Must().Pure()
+}
+
+// PureMustBiAnyOf is BiAnyOf for PureMustBiPredicate.
+func PureMustBiAnyOf[T, U any](ps ...PureMustBiPredicate[T, U]) PureMustBiPredicate[T, U] {
+	return BiAnyOf(liftPureMustBiPredicates(ps)...).Must().Pure()
+}
+
+// PureMustBiNoneOf is BiNoneOf for PureMustBiPredicate.
+func PureMustBiNoneOf[T, U any](ps ...PureMustBiPredicate[T, U]) PureMustBiPredicate[T, U] {
+	return BiNoneOf(liftPureMustBiPredicates(ps)...).Must().Pure()
+}
+
+// PureMustBiXor is BiXor for PureMustBiPredicate.
+func PureMustBiXor[T, U any](ps ...PureMustBiPredicate[T, U]) PureMustBiPredicate[T, U] {
+	return BiXor(liftPureMustBiPredicates(ps)...).Must().Pure()
+}
+
+// PureMustBiAtLeastN is BiAtLeastN for PureMustBiPredicate.
+func PureMustBiAtLeastN[T, U any](n int, ps ...PureMustBiPredicate[T, U]) PureMustBiPredicate[T, U] {
+	return BiAtLeastN(n, liftPureMustBiPredicates(ps)...).Must().Pure()
+}
+
+// PureMustBiExactlyN is BiExactlyN for PureMustBiPredicate.
+func PureMustBiExactlyN[T, U any](n int, ps ...PureMustBiPredicate[T, U]) PureMustBiPredicate[T, U] {
+	return BiExactlyN(n, liftPureMustBiPredicates(ps)...).Must().Pure()
+}
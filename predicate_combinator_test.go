@@ -0,0 +1,111 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Variadic predicate combinators", func() {
+	var calls int
+	constant := func(v bool) funk.Predicate[string] {
+		return func(ctx context.Context, s string) (context.Context, bool, error) {
+			calls++
+			return incCtxValue(ctx), v, nil
+		}
+	}
+	failing := func(ctx context.Context, s string) (context.Context, bool, error) {
+		calls++
+		return incCtxValue(ctx), true, errors.New("")
+	}
+	BeforeEach(func() {
+		calls = 0
+	})
+
+	DescribeTable("evaluating",
+		func(p func(...funk.Predicate[string]) funk.Predicate[string], values []bool, expected bool, evaluated int) {
+			ps := make([]funk.Predicate[string], len(values))
+			for i, v := range values {
+				ps[i] = constant(v)
+			}
+			ctx, v, err := p(ps...)(context.Background(), "")
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal(expected))
+			Expect(calls).To(Equal(evaluated))
+			Expect(getCtxValue(ctx)).To(Equal(evaluated))
+		},
+		Entry("AllOf with no predicate", funk.AllOf[string], []bool{}, true, 0),
+		Entry("AllOf with all true", funk.AllOf[string], []bool{true, true}, true, 2),
+		Entry("AllOf short-circuits on false", funk.AllOf[string], []bool{true, false, true}, false, 2),
+		Entry("AnyOf with no predicate", funk.AnyOf[string], []bool{}, false, 0),
+		Entry("AnyOf short-circuits on true", funk.AnyOf[string], []bool{false, true, false}, true, 2),
+		Entry("AnyOf with all false", funk.AnyOf[string], []bool{false, false}, false, 2),
+		Entry("NoneOf short-circuits on true", funk.NoneOf[string], []bool{false, true, false}, false, 2),
+		Entry("NoneOf with all false", funk.NoneOf[string], []bool{false, false}, true, 2),
+		Entry("Xor with odd trues", funk.Xor[string], []bool{true, true, true}, true, 3),
+		Entry("Xor with even trues", funk.Xor[string], []bool{true, false, true}, false, 3),
+	)
+
+	DescribeTable("counting",
+		func(p func(int, ...funk.Predicate[string]) funk.Predicate[string], n int, values []bool, expected bool,
+			evaluated int) {
+			ps := make([]funk.Predicate[string], len(values))
+			for i, v := range values {
+				ps[i] = constant(v)
+			}
+			ctx, v, err := p(n, ps...)(context.Background(), "")
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal(expected))
+			Expect(getCtxValue(ctx)).To(Equal(evaluated))
+		},
+		Entry("AtLeastN with zero", funk.AtLeastN[string], 0, []bool{false}, true, 0),
+		Entry("AtLeastN short-circuits on reaching n", funk.AtLeastN[string], 2, []bool{true, true, false}, true, 2),
+		Entry("AtLeastN short-circuits on unreachable n", funk.AtLeastN[string], 2, []bool{false, false, true}, false, 2),
+		Entry("AtLeastN with more than given", funk.AtLeastN[string], 2, []bool{true}, false, 0),
+		Entry("ExactlyN matches", funk.ExactlyN[string], 1, []bool{false, true, false}, true, 3),
+		Entry("ExactlyN short-circuits on exceeding n", funk.ExactlyN[string], 1, []bool{true, true, false}, false, 2),
+		Entry("ExactlyN short-circuits on unreachable n", funk.ExactlyN[string], 2, []bool{false, false, true}, false, 2),
+	)
+
+	When("A predicate returns error", func() {
+		It("should stop and return the value and error of the predicate as And and Or do", func() {
+			ctx, v, err := funk.AnyOf(constant(false), failing, constant(true))(context.Background(), "")
+			Expect(err).To(HaveOccurred())
+			Expect(v).To(BeTrue())
+			Expect(getCtxValue(ctx)).To(Equal(2))
+			Expect(calls).To(Equal(2))
+			_, orV, orErr := constant(false).Or(failing)(context.Background(), "")
+			Expect(orErr).To(HaveOccurred())
+			Expect(v).To(Equal(orV))
+		})
+	})
+
+	Describe("Other variants", func() {
+		It("should support MustPredicate", func() {
+			ctx, v := funk.MustAllOf(constant(true).Must(), constant(true).Must())(context.Background(), "")
+			Expect(v).To(BeTrue())
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
+		It("should support PurePredicate", func() {
+			_, err := funk.PureAllOf(constant(true).Pure(), funk.Predicate[string](failing).Pure())("")
+			Expect(err).To(HaveOccurred())
+		})
+		It("should support PureMustPredicate", func() {
+			Expect(funk.PureMustXor(funk.Eq(1), funk.Gt(0))(1)).To(BeFalse())
+			Expect(funk.PureMustExactlyN(1, funk.Eq(1), funk.Gt(0))(2)).To(BeTrue())
+		})
+		It("should support BiPredicate", func() {
+			lt := funk.PureMustBiPredicate[int, int](func(a, b int) bool { return a < b })
+			eq := funk.PureMustBiPredicate[int, int](func(a, b int) bool { return a == b })
+			Expect(funk.PureMustBiAnyOf(lt, eq)(1, 1)).To(BeTrue())
+			Expect(funk.PureMustBiNoneOf(lt, eq)(2, 1)).To(BeTrue())
+			_, v, err := funk.BiAtLeastN(2, lt.Lift(), eq.Lift())(context.Background(), 1, 2)
+			Expect(v).To(BeFalse())
+			Expect(err).To(Not(HaveOccurred()))
+		})
+	})
+})
@@ -0,0 +1,630 @@
+package funk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseError represents an error of a predicate expression at a byte offset of the source.
+type ParseError struct {
+	Offset int
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("offset %d: %v", e.Offset, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// valueKind is the kind a field value is normalized to, so that values of different types with the same kind can be
+// compared with the same predicates.
+type valueKind int
+
+const (
+	kindUnsupported valueKind = iota
+	kindBool
+	kindInt
+	kindUint
+	kindFloat
+	kindString
+)
+
+func (k valueKind) String() string {
+	return [...]string{"unsupported", "bool", "int", "uint", "float", "string"}[k]
+}
+
+func kindOf(t reflect.Type) valueKind {
+	switch t.Kind() {
+	case reflect.Bool:
+		return kindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return kindInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return kindUint
+	case reflect.Float32, reflect.Float64:
+		return kindFloat
+	case reflect.String:
+		return kindString
+	default:
+		return kindUnsupported
+	}
+}
+
+// normalize converts v to bool, int64, uint64, float64 or string by its kind.
+func normalize(v reflect.Value, k valueKind) any {
+	switch k {
+	case kindBool:
+		return v.Bool()
+	case kindInt:
+		return v.Int()
+	case kindUint:
+		return v.Uint()
+	case kindFloat:
+		return v.Float()
+	default:
+		return v.String()
+	}
+}
+
+// field represents a field of T accessible in predicate expressions, its getter returns normalized values.
+type field[T any] struct {
+	typ  reflect.Type
+	kind valueKind
+	get  Func[T, any]
+}
+
+// PredicateParser compiles textual expressions such as `age >= 18 && (country in ["DE", "FR"] || vip)` to
+// Predicate over T.
+//
+// An expression consists of comparisons of a field with a literal by ==, !=, <, <=, > and >=, membership tests of
+// a field in a list of literals by in, and bool fields, composed by &&, ||, ! and parentheses.
+// Literals are numbers, double-quoted strings, true and false.
+//
+// Fields are resolved from the getters registered by RegisterGetter first, and then from the exported fields of T or
+// *T if it's a struct. Struct fields are named after their `funk` tags, or matched by their names case-insensitively.
+// Fields of bool, string, integer and floating-point kinds are supported.
+type PredicateParser[T any] struct {
+	getters map[string]field[T]
+}
+
+// NewPredicateParser returns a PredicateParser without registered getters.
+func NewPredicateParser[T any]() *PredicateParser[T] {
+	return &PredicateParser[T]{getters: map[string]field[T]{}}
+}
+
+// RegisterGetter registers get as the field named name of the parser.
+func RegisterGetter[T, V any](p *PredicateParser[T], name string, get Func[T, V]) {
+	typ := reflect.TypeOf((*V)(nil)).Elem()
+	kind := kindOf(typ)
+	p.getters[name] = field[T]{
+		typ:  typ,
+		kind: kind,
+		get: func(ctx context.Context, t T) (context.Context, any, error) {
+			ctx, v, err := get(ctx, t)
+			if err != nil {
+				return ctx, nil, err
+			}
+			return ctx, normalize(reflect.ValueOf(&v).Elem(), kind), nil
+		},
+	}
+}
+
+// ParsePredicate compiles src to Predicate over T with the fields of T, see PredicateParser for the syntax.
+func ParsePredicate[T any](src string) (Predicate[T], error) {
+	return NewPredicateParser[T]().Parse(src)
+}
+
+// Parse compiles src to Predicate over T, leaves of the predicate are named after their sources when explained.
+// Syntax errors, unknown fields and mismatched types are reported as *ParseError.
+func (p *PredicateParser[T]) Parse(src string) (Predicate[T], error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	ps := &parser[T]{src: src, tokens: tokens, fields: p}
+	pred, err := ps.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := ps.peek(); tok.kind != tokenEOF {
+		return nil, ps.errorf(tok, "unexpected %s", tok)
+	}
+	return pred, nil
+}
+
+// CompareFactory returns a PredicateFactory of LeafCompare that compares the fields of the parser.
+func (p *PredicateParser[T]) CompareFactory() PredicateFactory[T] {
+	return func(params json.RawMessage) (Predicate[T], error) {
+		c, literals, err := decodeComparison(params)
+		if err != nil {
+			return nil, err
+		}
+		f, ok := p.field(c.Field)
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", c.Field)
+		}
+		if f.kind == kindUnsupported {
+			return nil, fmt.Errorf("field %q has unsupported type %s", c.Field, f.typ)
+		}
+		if f.kind == kindBool && c.Op != "==" && c.Op != "!=" && c.Op != "in" {
+			return nil, fmt.Errorf("operator %q is not defined on bool field %q", c.Op, c.Field)
+		}
+		values := make([]any, len(literals))
+		for i, l := range literals {
+			if values[i], err = f.convert(l); err != nil {
+				return nil, err
+			}
+		}
+		return f.compare(c.Op, values), nil
+	}
+}
+
+func (p *PredicateParser[T]) field(name string) (field[T], bool) {
+	if f, ok := p.getters[name]; ok {
+		return f, true
+	}
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	ptr := typ.Kind() == reflect.Pointer
+	if ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return field[T]{}, false
+	}
+	sf, ok := structField(typ, name)
+	if !ok {
+		return field[T]{}, false
+	}
+	kind := kindOf(sf.Type)
+	return field[T]{
+		typ:  sf.Type,
+		kind: kind,
+		get: func(ctx context.Context, t T) (context.Context, any, error) {
+			v := reflect.ValueOf(&t).Elem()
+			if ptr {
+				if v.IsNil() {
+					return ctx, nil, fmt.Errorf("access field %q of nil pointer", name)
+				}
+				v = v.Elem()
+			}
+			fv, err := v.FieldByIndexErr(sf.Index)
+			if err != nil {
+				return ctx, nil, fmt.Errorf("access field %q: %w", name, err)
+			}
+			return ctx, normalize(fv, kind), nil
+		},
+	}, true
+}
+
+func structField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for _, sf := range reflect.VisibleFields(typ) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+		if tag, ok := sf.Tag.Lookup("funk"); ok {
+			if tag == name {
+				return sf, true
+			}
+			continue
+		}
+		if strings.EqualFold(sf.Name, name) {
+			return sf, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOp
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+var lexOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", "[", "]", ","}
+
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case c == utf8.RuneError && size == 1:
+			return nil, &ParseError{Offset: i, Err: errors.New("invalid UTF-8")}
+		case unicode.IsSpace(c):
+			i += size
+		case c == '_' || unicode.IsLetter(c):
+			j := i + size
+			for j < len(src) {
+				r, n := utf8.DecodeRuneInString(src[j:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				j += n
+			}
+			tokens = append(tokens, token{tokenIdent, src[i:j], i})
+			i = j
+		case c == '-' || c == '.' || c >= '0' && c <= '9':
+			j := i + 1
+			for j < len(src) && strings.ContainsRune("0123456789.eE+-_xXabcdefABCDEF", rune(src[j])) {
+				if (src[j] == '+' || src[j] == '-') && src[j-1] != 'e' && src[j-1] != 'E' {
+					break
+				}
+				j++
+			}
+			tokens = append(tokens, token{tokenNumber, src[i:j], i})
+			i = j
+		case c == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, &ParseError{Offset: i, Err: errors.New("unterminated string")}
+			}
+			tokens = append(tokens, token{tokenString, src[i : j+1], i})
+			i = j + 1
+		default:
+			op := ""
+			for _, o := range lexOperators {
+				if strings.HasPrefix(src[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, &ParseError{Offset: i, Err: fmt.Errorf("unexpected character %q", c)}
+			}
+			tokens = append(tokens, token{tokenOp, op, i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, offset: len(src)}), nil
+}
+
+type parser[T any] struct {
+	src    string
+	tokens []token
+	pos    int
+	fields *PredicateParser[T]
+}
+
+func (p *parser[T]) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser[T]) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser[T]) accept(op string) bool {
+	if tok := p.peek(); tok.kind == tokenOp && tok.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser[T]) expect(op string) error {
+	if !p.accept(op) {
+		tok := p.peek()
+		return p.errorf(tok, "expected %q, got %s", op, tok)
+	}
+	return nil
+}
+
+func (p *parser[T]) errorf(tok token, format string, args ...any) error {
+	return &ParseError{Offset: tok.offset, Err: fmt.Errorf(format, args...)}
+}
+
+func (p *parser[T]) parseOr() (Predicate[T], error) {
+	pred, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		other, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		pred = pred.Or(other)
+	}
+	return pred, nil
+}
+
+func (p *parser[T]) parseAnd() (Predicate[T], error) {
+	pred, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		other, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		pred = pred.And(other)
+	}
+	return pred, nil
+}
+
+func (p *parser[T]) parseUnary() (Predicate[T], error) {
+	if p.accept("!") {
+		pred, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return pred.Not(), nil
+	}
+	if p.accept("(") {
+		pred, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return pred, p.expect(")")
+	}
+	return p.parseComparison()
+}
+
+func (p *parser[T]) parseComparison() (Predicate[T], error) {
+	start := p.peek()
+	if start.kind != tokenIdent || start.text == "true" || start.text == "false" || start.text == "in" {
+		return nil, p.errorf(start, "expected field, got %s", start)
+	}
+	p.next()
+	f, ok := p.fields.field(start.text)
+	if !ok {
+		return nil, p.errorf(start, "unknown field %q", start.text)
+	}
+	if f.kind == kindUnsupported {
+		return nil, p.errorf(start, "field %q has unsupported type %s", start.text, f.typ)
+	}
+
+	op, values := "==", []any{true}
+	tok := p.peek()
+	switch {
+	case tok.kind == tokenIdent && tok.text == "in":
+		p.next()
+		list, err := p.parseList(f)
+		if err != nil {
+			return nil, err
+		}
+		op, values = "in", list
+	case tok.kind == tokenOp && isComparison(tok.text):
+		p.next()
+		if f.kind == kindBool && tok.text != "==" && tok.text != "!=" {
+			return nil, p.errorf(tok, "operator %q is not defined on bool field %q", tok.text, start.text)
+		}
+		v, err := p.parseLiteral(f)
+		if err != nil {
+			return nil, err
+		}
+		op, values = tok.text, []any{v}
+	default:
+		if f.kind != kindBool {
+			return nil, p.errorf(tok, "expected comparison of %s field %q, got %s", f.kind, start.text, tok)
+		}
+	}
+
+	end := p.tokens[p.pos-1]
+	return f.compare(op, values).Named(p.src[start.offset : end.offset+len(end.text)]), nil
+}
+
+func (p *parser[T]) parseList(f field[T]) ([]any, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	var values []any
+	if p.accept("]") {
+		return values, nil
+	}
+	for {
+		v, err := p.parseLiteral(f)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+		if p.accept("]") {
+			return values, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseLiteral parses a literal and converts it to the normalized value of the kind of f.
+func (p *parser[T]) parseLiteral(f field[T]) (any, error) {
+	tok := p.next()
+	var raw any
+	switch tok.kind {
+	case tokenNumber:
+		raw = json.Number(tok.text)
+	case tokenString:
+		s, err := strconv.Unquote(tok.text)
+		if err != nil {
+			return nil, p.errorf(tok, "invalid string literal %s", tok)
+		}
+		raw = s
+	case tokenIdent:
+		if tok.text != "true" && tok.text != "false" {
+			return nil, p.errorf(tok, "expected literal, got %s", tok)
+		}
+		raw = tok.text == "true"
+	default:
+		return nil, p.errorf(tok, "expected literal, got %s", tok)
+	}
+	v, err := f.convert(raw)
+	if err != nil {
+		return nil, p.errorf(tok, "%v", err)
+	}
+	return v, nil
+}
+
+// convert converts a literal of json.Number, string or bool to the normalized value of the kind of f.
+func (f field[T]) convert(literal any) (any, error) {
+	mismatch := func() error {
+		return fmt.Errorf("can't compare %s field with %s", f.kind, formatLiteral(literal))
+	}
+	switch l := literal.(type) {
+	case json.Number:
+		var v any
+		var err error
+		switch f.kind {
+		case kindInt:
+			v, err = strconv.ParseInt(string(l), 0, f.typ.Bits())
+		case kindUint:
+			v, err = strconv.ParseUint(string(l), 0, f.typ.Bits())
+		case kindFloat:
+			v, err = strconv.ParseFloat(string(l), f.typ.Bits())
+		default:
+			return nil, mismatch()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s literal %s", f.kind, l)
+		}
+		return v, nil
+	case string:
+		if f.kind != kindString {
+			return nil, mismatch()
+		}
+		return l, nil
+	case bool:
+		if f.kind != kindBool {
+			return nil, mismatch()
+		}
+		return l, nil
+	default:
+		return nil, fmt.Errorf("unsupported literal %v", literal)
+	}
+}
+
+func formatLiteral(literal any) string {
+	if s, ok := literal.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(literal)
+}
+
+// compare returns a Predicate that compares the field with values by op, values are normalized and there is exactly
+// one value unless op is in.
+func (f field[T]) compare(op string, values []any) Predicate[T] {
+	var match func(any) bool
+	if op == "in" {
+		match = inValues(f.kind, values)
+	} else {
+		match = compareValue(f.kind, op, values[0])
+	}
+	get := f.get
+	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		ctx, v, err := get(ctx, t)
+		if err != nil {
+			return ctx, false, err
+		}
+		return ctx, match(v), nil
+	}
+}
+
+func isComparison(op string) bool {
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	default:
+		return false
+	}
+}
+
+func compareValue(kind valueKind, op string, v any) func(any) bool {
+	switch kind {
+	case kindBool:
+		if op == "==" {
+			return testValue(Eq(v.(bool)))
+		}
+		return testValue(Ne(v.(bool)))
+	case kindInt:
+		return testValue(compareOrdered(op, v.(int64)))
+	case kindUint:
+		return testValue(compareOrdered(op, v.(uint64)))
+	case kindFloat:
+		return testValue(compareOrdered(op, v.(float64)))
+	default:
+		return testValue(compareOrdered(op, v.(string)))
+	}
+}
+
+func compareOrdered[V int64 | uint64 | float64 | string](op string, v V) PureMustPredicate[V] {
+	switch op {
+	case "==":
+		return Eq(v)
+	case "!=":
+		return Ne(v)
+	case "<":
+		return Lt(v)
+	case "<=":
+		return Le(v)
+	case ">":
+		return Gt(v)
+	default:
+		return Ge(v)
+	}
+}
+
+func inValues(kind valueKind, values []any) func(any) bool {
+	switch kind {
+	case kindBool:
+		return testValue(In(convertValues[bool](values)...))
+	case kindInt:
+		return testValue(In(convertValues[int64](values)...))
+	case kindUint:
+		return testValue(In(convertValues[uint64](values)...))
+	case kindFloat:
+		return testValue(In(convertValues[float64](values)...))
+	default:
+		return testValue(In(convertValues[string](values)...))
+	}
+}
+
+func convertValues[V any](values []any) []V {
+	vs := make([]V, len(values))
+	for i, v := range values {
+		vs[i] = v.(V)
+	}
+	return vs
+}
+
+func testValue[V any](p PureMustPredicate[V]) func(any) bool {
+	return func(v any) bool {
+		return p(v.(V))
+	}
+}
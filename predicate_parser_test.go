@@ -0,0 +1,168 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Parsing predicates", func() {
+	type customer struct {
+		Age     int
+		Country string
+		VIP     bool
+		Score   float64 `funk:"rating"`
+		Visits  uint8
+		Tags    []string
+	}
+	evaluate := func(p funk.Predicate[customer], c customer) bool {
+		_, v, err := p(context.Background(), c)
+		Expect(err).To(Not(HaveOccurred()))
+		return v
+	}
+
+	It("should compile expressions over struct fields", func() {
+		p, err := funk.ParsePredicate[customer](`age >= 18 && (country in ["DE","FR"] || vip)`)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(evaluate(p, customer{Age: 20, Country: "DE"})).To(BeTrue())
+		Expect(evaluate(p, customer{Age: 20, Country: "US"})).To(BeFalse())
+		Expect(evaluate(p, customer{Age: 20, Country: "US", VIP: true})).To(BeTrue())
+		Expect(evaluate(p, customer{Age: 17, Country: "DE"})).To(BeFalse())
+	})
+
+	DescribeTable("evaluating",
+		func(src string, c customer, expected bool) {
+			p, err := funk.ParsePredicate[customer](src)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(evaluate(p, c)).To(Equal(expected))
+		},
+		Entry("not", `!vip`, customer{}, true),
+		Entry("bool comparison", `vip != true`, customer{VIP: true}, false),
+		Entry("string comparison", `country < "E"`, customer{Country: "DE"}, true),
+		Entry("tagged float field", `rating > 4.5`, customer{Score: 4.6}, true),
+		Entry("uint field", `visits == 0x10`, customer{Visits: 16}, true),
+		Entry("negative number", `age > -1`, customer{}, true),
+		Entry("empty list", `age in []`, customer{}, false),
+		Entry("precedence", `vip || age > 1 && age < 3`, customer{VIP: true, Age: 5}, true),
+		Entry("escaped string", `country == "a\"b"`, customer{Country: `a"b`}, true),
+		Entry("non-ASCII string", `country == "Köln"`, customer{Country: "Köln"}, true),
+	)
+
+	DescribeTable("reporting errors at compile time",
+		func(src string, offset int, msg string) {
+			_, err := funk.ParsePredicate[customer](src)
+			var parseErr *funk.ParseError
+			Expect(errors.As(err, &parseErr)).To(BeTrue())
+			Expect(parseErr.Offset).To(Equal(offset))
+			Expect(err.Error()).To(ContainSubstring(msg))
+		},
+		Entry("unknown field", `age > 1 && name == "a"`, 11, `unknown field "name"`),
+		Entry("mismatched literal", `age == "18"`, 7, "can't compare int field"),
+		Entry("fractional integer", `age == 1.5`, 7, "invalid int literal"),
+		Entry("overflow", `visits < 256`, 9, "invalid uint literal"),
+		Entry("ordering bool", `vip < true`, 4, "not defined on bool field"),
+		Entry("non-bool field alone", `age && vip`, 4, "expected comparison of int field"),
+		Entry("unsupported field", `tags == "a"`, 0, "unsupported type"),
+		Entry("unbalanced parenthesis", `(vip`, 4, `expected ")"`),
+		Entry("trailing tokens", `vip vip`, 4, "unexpected"),
+		Entry("unterminated string", `country == "DE`, 11, "unterminated string"),
+		Entry("unexpected character", `age = 1`, 4, "unexpected character"),
+		Entry("non-ASCII identifier", `age > 1 && größe > 1`, 11, `unknown field "größe"`),
+		Entry("non-ASCII character", `age > ٣`, 6, `unexpected character '٣'`),
+		Entry("invalid UTF-8", "age > \xff", 6, "invalid UTF-8"),
+	)
+
+	Describe("Registered getters", func() {
+		var parser *funk.PredicateParser[customer]
+		BeforeEach(func() {
+			parser = funk.NewPredicateParser[customer]()
+			funk.RegisterGetter(parser, "tags", funk.Func[customer, int](
+				func(ctx context.Context, c customer) (context.Context, int, error) {
+					return incCtxValue(ctx), len(c.Tags), nil
+				}))
+			funk.RegisterGetter(parser, "initial", funk.Func[customer, string](
+				func(ctx context.Context, c customer) (context.Context, string, error) {
+					if c.Country == "" {
+						return ctx, "", errors.New("no country")
+					}
+					return ctx, strings.ToLower(c.Country[:1]), nil
+				}))
+		})
+
+		It("should override struct fields and propagate context", func() {
+			p, err := parser.Parse(`tags >= 2 && vip`)
+			Expect(err).To(Not(HaveOccurred()))
+			ctx, v, err := p(context.Background(), customer{Tags: []string{"a", "b"}, VIP: true})
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(BeTrue())
+			Expect(getCtxValue(ctx)).To(Equal(1))
+		})
+		It("should return errors of getters", func() {
+			p, err := parser.Parse(`initial == "d"`)
+			Expect(err).To(Not(HaveOccurred()))
+			_, _, err = p(context.Background(), customer{})
+			Expect(err).To(MatchError("no country"))
+		})
+	})
+
+	Describe("Comparison leaves", func() {
+		var r *funk.PredicateRegistry[customer]
+		BeforeEach(func() {
+			r = funk.NewPredicateRegistry[customer]().
+				Register(funk.LeafCompare, funk.NewPredicateParser[customer]().CompareFactory())
+		})
+
+		It("should compile comparisons of fields", func() {
+			p, err := r.Compile(funk.ExprAnd(funk.ExprCompare("rating", ">", 4), funk.ExprIn("country", "DE")))
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(evaluate(p, customer{Score: 4.5, Country: "DE"})).To(BeTrue())
+			Expect(evaluate(p, customer{Score: 4.5, Country: "FR"})).To(BeFalse())
+		})
+		DescribeTable("reporting errors",
+			func(e *funk.Expr, msg string) {
+				_, err := r.Compile(e)
+				Expect(err).To(MatchError(ContainSubstring(msg)))
+			},
+			Entry("unknown field", funk.ExprCompare("name", "==", "a"), `unknown field "name"`),
+			Entry("mismatched value", funk.ExprCompare("age", "==", "18"), `can't compare int field with "18"`),
+			Entry("ordering bool", funk.ExprCompare("vip", ">", true), "not defined on bool field"),
+			Entry("unsupported value", funk.ExprIn("age", []int{1}), "unsupported value"),
+		)
+	})
+
+	It("should support pointers to struct", func() {
+		p, err := funk.ParsePredicate[*customer](`age == 1`)
+		Expect(err).To(Not(HaveOccurred()))
+		_, v, err := p(context.Background(), &customer{Age: 1})
+		Expect(v).To(BeTrue())
+		Expect(err).To(Not(HaveOccurred()))
+		_, _, err = p(context.Background(), nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error for fields promoted through nil embedded pointers", func() {
+		type Inner struct{ Age int }
+		type Outer struct{ *Inner }
+		p, err := funk.ParsePredicate[Outer](`age >= 18`)
+		Expect(err).To(Not(HaveOccurred()))
+		_, v, err := p(context.Background(), Outer{Inner: &Inner{Age: 20}})
+		Expect(v).To(BeTrue())
+		Expect(err).To(Not(HaveOccurred()))
+		_, _, err = p(context.Background(), Outer{})
+		Expect(err).To(MatchError(ContainSubstring(`access field "age"`)))
+	})
+
+	It("should name leaves after their sources", func() {
+		p, _ := funk.ParsePredicate[customer](`age >= 18 && country in ["DE", "FR"]`)
+		_, e := p.Explain(context.Background(), customer{Age: 20, Country: "US"})
+		Expect(e.String()).To(Equal(`and: false
+  age >= 18: true
+  country in ["DE", "FR"]: false
+`))
+	})
+})
@@ -3,6 +3,7 @@ package funk_test
 import (
 	"context"
 	"errors"
+	"testing"
 
 	funk "github.com/hongcankun/gofunk"
 
@@ -2213,3 +2214,55 @@ var _ = Describe("PureMustBiPredicate", func() {
 		})
 	})
 })
+
+var _ = Describe("Lifting predicates", func() {
+	It("should lift MustPredicate to Predicate", func() {
+		p := funk.MustPredicate[string](func(ctx context.Context, s string) (context.Context, bool) {
+			return incCtxValue(ctx), s == "a"
+		}).Lift()
+		ctx, v, err := p(context.Background(), "a")
+		Expect(getCtxValue(ctx)).To(Equal(1))
+		Expect(v).To(BeTrue())
+		Expect(err).To(Not(HaveOccurred()))
+	})
+	It("should lift PurePredicate to Predicate", func() {
+		p := funk.PurePredicate[string](func(s string) (bool, error) {
+			return false, errors.New("")
+		}).Lift()
+		ctx, _, err := p(incCtxValue(context.Background()), "a")
+		Expect(getCtxValue(ctx)).To(Equal(1))
+		Expect(err).To(HaveOccurred())
+	})
+	It("should lift PureMustBiPredicate to BiPredicate", func() {
+		p := funk.PureMustBiPredicate[int, int](func(a, b int) bool {
+			return a < b
+		}).Lift()
+		_, v, err := p(context.Background(), 1, 2)
+		Expect(v).To(BeTrue())
+		Expect(err).To(Not(HaveOccurred()))
+	})
+})
+
+func BenchmarkPredicateCombinators(b *testing.B) {
+	ctx := context.Background()
+	b.Run("Predicate", func(b *testing.B) {
+		p := funk.Predicate[int](func(ctx context.Context, i int) (context.Context, bool, error) {
+			return ctx, i > 0, nil
+		})
+		q := p.And(p).Or(p).Not()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _, _ = q(ctx, i)
+		}
+	})
+	b.Run("MustPredicate", func(b *testing.B) {
+		p := funk.MustPredicate[int](func(ctx context.Context, i int) (context.Context, bool) {
+			return ctx, i > 0
+		})
+		q := p.And(p).Or(p).Not()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = q(ctx, i)
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package funk
+
+import (
+	"cmp"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Eq returns a PureMustPredicate that tests if the argument is equal to v.
+func Eq[T comparable](v T) PureMustPredicate[T] {
+	return func(t T) bool {
+		return t == v
+	}
+}
+
+// Ne returns a PureMustPredicate that tests if the argument is not equal to v.
+func Ne[T comparable](v T) PureMustPredicate[T] {
+	return func(t T) bool {
+		return t != v
+	}
+}
+
+// Lt returns a PureMustPredicate that tests if the argument is less than v.
+func Lt[T cmp.Ordered](v T) PureMustPredicate[T] {
+	return func(t T) bool {
+		return t < v
+	}
+}
+
+// Le returns a PureMustPredicate that tests if the argument is less than or equal to v.
+func Le[T cmp.Ordered](v T) PureMustPredicate[T] {
+	return func(t T) bool {
+		return t <= v
+	}
+}
+
+// Gt returns a PureMustPredicate that tests if the argument is greater than v.
+func Gt[T cmp.Ordered](v T) PureMustPredicate[T] {
+	return func(t T) bool {
+		return t > v
+	}
+}
+
+// Ge returns a PureMustPredicate that tests if the argument is greater than or equal to v.
+func Ge[T cmp.Ordered](v T) PureMustPredicate[T] {
+	return func(t T) bool {
+		return t >= v
+	}
+}
+
+// Between returns a PureMustPredicate that tests if the argument is in the closed interval [lo, hi].
+func Between[T cmp.Ordered](lo, hi T) PureMustPredicate[T] {
+	return func(t T) bool {
+		return lo <= t && t <= hi
+	}
+}
+
+// In returns a PureMustPredicate that tests if the argument is equal to any of vs.
+func In[T comparable](vs ...T) PureMustPredicate[T] {
+	set := make(map[T]struct{}, len(vs))
+	for _, v := range vs {
+		set[v] = struct{}{}
+	}
+	return func(t T) bool {
+		_, ok := set[t]
+		return ok
+	}
+}
+
+// IsZero returns a PureMustPredicate that tests if the argument is the zero value of its type.
+func IsZero[T comparable]() PureMustPredicate[T] {
+	var zero T
+	return Eq(zero)
+}
+
+// IsNil returns a PureMustPredicate that tests if the argument is nil.
+// Arguments whose kind can't be nil, such as int or struct, are never nil.
+func IsNil[T any]() PureMustPredicate[T] {
+	return func(t T) bool {
+		v := reflect.ValueOf(&t).Elem()
+		switch v.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice,
+			reflect.UnsafePointer:
+			return v.IsNil()
+		default:
+			return false
+		}
+	}
+}
+
+// HasPrefix returns a PureMustPredicate that tests if the argument begins with prefix.
+func HasPrefix(prefix string) PureMustPredicate[string] {
+	return func(s string) bool {
+		return strings.HasPrefix(s, prefix)
+	}
+}
+
+// Contains returns a PureMustPredicate that tests if substr is within the argument.
+func Contains(substr string) PureMustPredicate[string] {
+	return func(s string) bool {
+		return strings.Contains(s, substr)
+	}
+}
+
+// MatchesRegexp returns a PureMustPredicate that tests if the argument contains any match of re.
+func MatchesRegexp(re *regexp.Regexp) PureMustPredicate[string] {
+	return re.MatchString
+}
+
+// LenBetween returns a PureMustPredicate that tests if the length in bytes of the argument is in the closed
+// interval [min, max].
+func LenBetween(min, max int) PureMustPredicate[string] {
+	return func(s string) bool {
+		return min <= len(s) && len(s) <= max
+	}
+}
+
+// ContainsAll returns a PureMustPredicate that tests if the argument contains every element of vs.
+func ContainsAll[S ~[]E, E comparable](vs ...E) PureMustPredicate[S] {
+	return func(s S) bool {
+		set := make(map[E]struct{}, len(s))
+		for _, e := range s {
+			set[e] = struct{}{}
+		}
+		for _, v := range vs {
+			if _, ok := set[v]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// IsEmpty returns a PureMustPredicate that tests if the argument has no elements.
+func IsEmpty[S ~[]E, E any]() PureMustPredicate[S] {
+	return func(s S) bool {
+		return len(s) == 0
+	}
+}
+
+// Before returns a PureMustPredicate that tests if the argument is before v.
+func Before(v time.Time) PureMustPredicate[time.Time] {
+	return func(t time.Time) bool {
+		return t.Before(v)
+	}
+}
+
+// After returns a PureMustPredicate that tests if the argument is after v.
+func After(v time.Time) PureMustPredicate[time.Time] {
+	return func(t time.Time) bool {
+		return t.After(v)
+	}
+}
+
+// Within returns a PureMustPredicate that tests if the argument is at most d away from the current time, in either
+// direction. The current time is read on every evaluation.
+func Within(d time.Duration) PureMustPredicate[time.Time] {
+	return func(t time.Time) bool {
+		diff := time.Since(t)
+		if diff < 0 {
+			diff = -diff
+		}
+		return diff <= d
+	}
+}
@@ -0,0 +1,109 @@
+package funk_test
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Predicates", func() {
+	Describe("Ordered and comparable predicates", func() {
+		It("should compare with the given value", func() {
+			Expect(funk.Eq(1)(1)).To(BeTrue())
+			Expect(funk.Eq(1)(2)).To(BeFalse())
+			Expect(funk.Ne(1)(2)).To(BeTrue())
+			Expect(funk.Lt(2)(1)).To(BeTrue())
+			Expect(funk.Lt(2)(2)).To(BeFalse())
+			Expect(funk.Le(2)(2)).To(BeTrue())
+			Expect(funk.Gt("a")("b")).To(BeTrue())
+			Expect(funk.Ge(2.0)(1.5)).To(BeFalse())
+		})
+		It("should test closed intervals", func() {
+			p := funk.Between(1, 3)
+			Expect(p(0)).To(BeFalse())
+			Expect(p(1)).To(BeTrue())
+			Expect(p(3)).To(BeTrue())
+			Expect(p(4)).To(BeFalse())
+		})
+		It("should test membership", func() {
+			p := funk.In("DE", "FR")
+			Expect(p("FR")).To(BeTrue())
+			Expect(p("US")).To(BeFalse())
+			Expect(funk.In[int]()(0)).To(BeFalse())
+		})
+	})
+
+	Describe("Zero and nil predicates", func() {
+		It("should test zero values", func() {
+			Expect(funk.IsZero[int]()(0)).To(BeTrue())
+			Expect(funk.IsZero[string]()("a")).To(BeFalse())
+		})
+		It("should test nil values", func() {
+			var ptr *int
+			var m map[string]int
+			Expect(funk.IsNil[*int]()(ptr)).To(BeTrue())
+			Expect(funk.IsNil[*int]()(new(int))).To(BeFalse())
+			Expect(funk.IsNil[map[string]int]()(m)).To(BeTrue())
+			Expect(funk.IsNil[error]()(nil)).To(BeTrue())
+			Expect(funk.IsNil[int]()(0)).To(BeFalse())
+		})
+	})
+
+	Describe("String predicates", func() {
+		It("should test strings", func() {
+			Expect(funk.HasPrefix("go")("gofunk")).To(BeTrue())
+			Expect(funk.HasPrefix("funk")("gofunk")).To(BeFalse())
+			Expect(funk.Contains("fun")("gofunk")).To(BeTrue())
+			Expect(funk.MatchesRegexp(regexp.MustCompile(`^\d+$`))("123")).To(BeTrue())
+			Expect(funk.MatchesRegexp(regexp.MustCompile(`^\d+$`))("12a")).To(BeFalse())
+			Expect(funk.LenBetween(1, 3)("")).To(BeFalse())
+			Expect(funk.LenBetween(1, 3)("abc")).To(BeTrue())
+		})
+	})
+
+	Describe("Slice predicates", func() {
+		It("should test slices", func() {
+			Expect(funk.ContainsAll[[]int](1, 2)([]int{3, 2, 1})).To(BeTrue())
+			Expect(funk.ContainsAll[[]int](1, 4)([]int{3, 2, 1})).To(BeFalse())
+			Expect(funk.IsEmpty[[]int]()(nil)).To(BeTrue())
+			Expect(funk.IsEmpty[[]int]()([]int{1})).To(BeFalse())
+		})
+	})
+
+	Describe("Time predicates", func() {
+		It("should test times", func() {
+			now := time.Now()
+			Expect(funk.Before(now)(now.Add(-time.Second))).To(BeTrue())
+			Expect(funk.After(now)(now.Add(-time.Second))).To(BeFalse())
+			Expect(funk.Within(time.Minute)(now.Add(30 * time.Second))).To(BeTrue())
+			Expect(funk.Within(time.Minute)(now.Add(-2 * time.Minute))).To(BeFalse())
+		})
+	})
+
+	Describe("Composing and lifting", func() {
+		It("should compose with logical operations", func() {
+			p := funk.Gt(0).And(funk.Lt(10)).Or(funk.Eq(100)).Not()
+			Expect(p(5)).To(BeFalse())
+			Expect(p(100)).To(BeFalse())
+			Expect(p(50)).To(BeTrue())
+		})
+		It("should be lifted to every variant", func() {
+			p := funk.Eq(1).Lift()
+			ctx, v, err := p(incCtxValue(context.Background()), 1)
+			Expect(getCtxValue(ctx)).To(Equal(1))
+			Expect(v).To(BeTrue())
+			Expect(err).To(Not(HaveOccurred()))
+
+			_, v = p.Must()(context.Background(), 2)
+			Expect(v).To(BeFalse())
+			v, err = p.Pure()(1)
+			Expect(v).To(BeTrue())
+			Expect(err).To(Not(HaveOccurred()))
+		})
+	})
+})
@@ -0,0 +1,410 @@
+package funk
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ErrSagaInterrupted is the cause of SagaError returned by Saga.Recover for sagas interrupted before finishing.
+var ErrSagaInterrupted = errors.New("saga interrupted")
+
+// SagaEvent represents the kind of SagaRecord.
+type SagaEvent string
+
+const (
+	// SagaStarted is recorded with the encoded argument before the first step is performed.
+	SagaStarted SagaEvent = "started"
+	// SagaStepCompleted is recorded after the action of a step succeeds.
+	SagaStepCompleted SagaEvent = "completed"
+	// SagaStepCompensated is recorded after the compensation of a step succeeds.
+	SagaStepCompensated SagaEvent = "compensated"
+	// SagaCompensationFailed is recorded after the compensation of a step fails.
+	SagaCompensationFailed SagaEvent = "compensationFailed"
+	// SagaFinished is recorded after all steps succeed or all completed steps are compensated.
+	SagaFinished SagaEvent = "finished"
+)
+
+// SagaRecord represents an entry of SagaJournal.
+type SagaRecord struct {
+	Saga  string          `json:"saga"`
+	ID    string          `json:"id"`
+	Event SagaEvent       `json:"event"`
+	Step  string          `json:"step,omitempty"`
+	Arg   json.RawMessage `json:"arg,omitempty"`
+	Err   string          `json:"error,omitempty"`
+}
+
+// SagaJournal persists the progress of sagas, so the compensations of interrupted sagas can be resumed.
+type SagaJournal interface {
+	// Append persists r, the saga must not proceed until it returns.
+	Append(r SagaRecord) error
+	// Records returns all persisted records in the order they were appended.
+	Records() ([]SagaRecord, error)
+}
+
+// SagaOutcome represents the outcome of a compensation.
+type SagaOutcome struct {
+	Step string
+	Err  error
+}
+
+// SagaError is returned when a saga fails, it reports the failed step and the outcome of each compensation in the
+// order they were performed.
+type SagaError struct {
+	Saga          string
+	ID            string
+	Step          string
+	Err           error
+	Compensations []SagaOutcome
+}
+
+func (e *SagaError) Error() string {
+	var b strings.Builder
+	if e.Step == "" {
+		fmt.Fprintf(&b, "saga %q (%s): %v", e.Saga, e.ID, e.Err)
+	} else {
+		fmt.Fprintf(&b, "saga %q (%s): step %q failed: %v", e.Saga, e.ID, e.Step, e.Err)
+	}
+	for _, o := range e.Compensations {
+		if o.Err == nil {
+			fmt.Fprintf(&b, "; compensated %q", o.Step)
+		} else {
+			fmt.Fprintf(&b, "; compensation of %q failed: %v", o.Step, o.Err)
+		}
+	}
+	return b.String()
+}
+
+// Unwrap returns the cause and the errors of the failed compensations.
+func (e *SagaError) Unwrap() []error {
+	errs := []error{e.Err}
+	for _, o := range e.Compensations {
+		if o.Err != nil {
+			errs = append(errs, o.Err)
+		}
+	}
+	return errs
+}
+
+// Compensated reports whether all compensations succeeded.
+func (e *SagaError) Compensated() bool {
+	for _, o := range e.Compensations {
+		if o.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+type sagaStep[T any] struct {
+	name         string
+	action       Consumer[T]
+	compensation Consumer[T]
+}
+
+// Saga performs a sequence of steps on an argument, each step is an action paired with a compensation. If a step
+// fails, the compensations of the completed steps are performed in reverse order.
+type Saga[T any] struct {
+	name    string
+	steps   []sagaStep[T]
+	journal SagaJournal
+}
+
+// NewSaga returns an empty Saga, the name identifies its records in the journal.
+func NewSaga[T any](name string) *Saga[T] {
+	return &Saga[T]{name: name}
+}
+
+// Step appends a step to this saga, compensation may be nil if the action needs no compensation.
+// Compensations should be idempotent: if a compensation succeeds but its SagaStepCompensated record fails to be
+// appended, the compensation is reported as failed and performed again by Recover.
+// It panics if the name is already taken.
+func (s *Saga[T]) Step(name string, action, compensation Consumer[T]) *Saga[T] {
+	if s.step(name) != nil {
+		panic(fmt.Sprintf("funk: saga step %q already added", name))
+	}
+	s.steps = append(s.steps, sagaStep[T]{name: name, action: action, compensation: compensation})
+	return s
+}
+
+// WithJournal makes this saga record its progress to j, the argument must be encodable as JSON.
+func (s *Saga[T]) WithJournal(j SagaJournal) *Saga[T] {
+	s.journal = j
+	return s
+}
+
+// Consumer returns a Consumer that performs the steps of this saga in order, threading the context through each
+// action. On failure, it performs the compensations of the completed steps in reverse order with the context without
+// cancellation, and returns SagaError. The saga is identified by the ID from WithSagaID, or a random ID if absent.
+// A failure to record the progress fails the current step.
+func (s *Saga[T]) Consumer() Consumer[T] {
+	return func(ctx context.Context, t T) (context.Context, error) {
+		id := sagaID(ctx)
+		if s.journal != nil {
+			arg, err := json.Marshal(t)
+			if err != nil {
+				return ctx, err
+			}
+			if err := s.record(id, SagaStarted, "", arg, nil); err != nil {
+				return ctx, err
+			}
+		}
+		for i, step := range s.steps {
+			var err error
+			if ctx, err = step.action(ctx, t); err != nil {
+				return ctx, s.compensate(ctx, id, t, step.name, err, s.steps[:i])
+			}
+			if err = s.record(id, SagaStepCompleted, step.name, nil, nil); err != nil {
+				return ctx, s.compensate(ctx, id, t, step.name, err, s.steps[:i+1])
+			}
+		}
+		return ctx, s.record(id, SagaFinished, "", nil, nil)
+	}
+}
+
+// Recover performs the pending compensations of the sagas in the journal that were interrupted before finishing.
+// It returns the joined SagaError of the sagas whose compensations failed, they are retried by the next Recover.
+func (s *Saga[T]) Recover(ctx context.Context) error {
+	if s.journal == nil {
+		return nil
+	}
+	records, err := s.journal.Records()
+	if err != nil {
+		return err
+	}
+	type pending struct {
+		arg       json.RawMessage
+		completed []string
+	}
+	var ids []string
+	sagas := map[string]*pending{}
+	for _, r := range records {
+		if r.Saga != s.name {
+			continue
+		}
+		switch r.Event {
+		case SagaStarted:
+			ids = append(ids, r.ID)
+			sagas[r.ID] = &pending{arg: r.Arg}
+		case SagaStepCompleted:
+			if p := sagas[r.ID]; p != nil {
+				p.completed = append(p.completed, r.Step)
+			}
+		case SagaStepCompensated:
+			if p := sagas[r.ID]; p != nil {
+				for i, name := range p.completed {
+					if name == r.Step {
+						p.completed = append(p.completed[:i], p.completed[i+1:]...)
+						break
+					}
+				}
+			}
+		case SagaFinished:
+			delete(sagas, r.ID)
+		}
+	}
+
+	var errs []error
+	for _, id := range ids {
+		p := sagas[id]
+		if p == nil {
+			continue
+		}
+		// An ID reused by later runs is started again, so it's listed more than once but must be compensated once.
+		delete(sagas, id)
+		var t T
+		if err := json.Unmarshal(p.arg, &t); err != nil {
+			errs = append(errs, fmt.Errorf("saga %q (%s): %w", s.name, id, err))
+			continue
+		}
+		steps := make([]sagaStep[T], len(p.completed))
+		for i, name := range p.completed {
+			step := s.step(name)
+			if step == nil {
+				step = &sagaStep[T]{name: name, compensation: func(ctx context.Context, _ T) (context.Context, error) {
+					return ctx, fmt.Errorf("unknown step %q", name)
+				}}
+			}
+			steps[i] = *step
+		}
+		var se *SagaError
+		if errors.As(s.compensate(ctx, id, t, "", ErrSagaInterrupted, steps), &se) && !se.Compensated() {
+			errs = append(errs, se)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Saga[T]) compensate(ctx context.Context, id string, t T, failed string, cause error, completed []sagaStep[T]) error {
+	se := &SagaError{Saga: s.name, ID: id, Step: failed, Err: cause}
+	ctx = context.WithoutCancel(ctx)
+	for i := len(completed) - 1; i >= 0; i-- {
+		step := completed[i]
+		var err error
+		if step.compensation != nil {
+			ctx, err = step.compensation(ctx, t)
+		}
+		if err == nil {
+			err = s.record(id, SagaStepCompensated, step.name, nil, nil)
+		} else if rerr := s.record(id, SagaCompensationFailed, step.name, nil, err); rerr != nil {
+			err = errors.Join(err, rerr)
+		}
+		se.Compensations = append(se.Compensations, SagaOutcome{Step: step.name, Err: err})
+	}
+	if se.Compensated() {
+		if err := s.record(id, SagaFinished, "", nil, nil); err != nil {
+			se.Compensations = append(se.Compensations, SagaOutcome{Err: err})
+		}
+	}
+	return se
+}
+
+func (s *Saga[T]) record(id string, event SagaEvent, step string, arg json.RawMessage, err error) error {
+	if s.journal == nil {
+		return nil
+	}
+	r := SagaRecord{Saga: s.name, ID: id, Event: event, Step: step, Arg: arg}
+	if err != nil {
+		r.Err = err.Error()
+	}
+	return s.journal.Append(r)
+}
+
+func (s *Saga[T]) step(name string) *sagaStep[T] {
+	for i := range s.steps {
+		if s.steps[i].name == name {
+			return &s.steps[i]
+		}
+	}
+	return nil
+}
+
+type sagaIDKey struct{}
+
+// WithSagaID returns a context that makes sagas performed with it identified by id.
+func WithSagaID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, sagaIDKey{}, id)
+}
+
+func sagaID(ctx context.Context) string {
+	if id, ok := ctx.Value(sagaIDKey{}).(string); ok {
+		return id
+	}
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// FileJournal is a SagaJournal that appends records to a local file as JSON lines, and syncs the file after each
+// record. It's safe for concurrent use.
+type FileJournal struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenFileJournal opens or creates the journal file at path. A truncated last line left by a crash is removed, so
+// the records appended later are not corrupted by it.
+func OpenFileJournal(path string) (*FileJournal, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := truncateToLastLine(f); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &FileJournal{file: f}, nil
+}
+
+// truncateToLastLine truncates f after its last newline.
+func truncateToLastLine(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+	end := size
+	buf := make([]byte, 4096)
+	for end > 0 {
+		n := int64(len(buf))
+		if n > end {
+			n = end
+		}
+		if _, err := f.ReadAt(buf[:n], end-n); err != nil {
+			return err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			end = end - n + int64(i) + 1
+			break
+		}
+		end -= n
+	}
+	if end == size {
+		return nil
+	}
+	if err := f.Truncate(end); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// Append writes r as a line and syncs the file. If the line fails to be written, the partial line is removed.
+func (j *FileJournal) Append(r SagaRecord) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	info, err := j.file.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return errors.Join(err, j.file.Truncate(info.Size()))
+	}
+	return j.file.Sync()
+}
+
+// Records reads all records of the file. A truncated last line left by a crash is ignored.
+func (j *FileJournal) Records() ([]SagaRecord, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, err := j.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(j.file)
+	if err != nil {
+		return nil, err
+	}
+	lines := bytes.Split(bytes.TrimSuffix(data, []byte("\n")), []byte("\n"))
+	records := make([]SagaRecord, 0, len(lines))
+	for i, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		var r SagaRecord
+		if err := json.Unmarshal(line, &r); err != nil {
+			if i == len(lines)-1 && !bytes.HasSuffix(data, []byte("\n")) {
+				break
+			}
+			return nil, fmt.Errorf("journal line %d: %w", i+1, err)
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// Close closes the file.
+func (j *FileJournal) Close() error {
+	return j.file.Close()
+}
@@ -0,0 +1,194 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Saga", func() {
+	var performed []string
+	step := func(name string, err error) funk.Consumer[int] {
+		return func(ctx context.Context, i int) (context.Context, error) {
+			performed = append(performed, name)
+			return incCtxValue(ctx), err
+		}
+	}
+	BeforeEach(func() {
+		performed = nil
+	})
+
+	It("should perform all steps", func() {
+		s := funk.NewSaga[int]("order").
+			Step("reserve", step("reserve", nil), step("release", nil)).
+			Step("charge", step("charge", nil), step("refund", nil))
+		ctx, err := s.Consumer()(context.Background(), 1)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(performed).To(Equal([]string{"reserve", "charge"}))
+		Expect(getCtxValue(ctx)).To(Equal(2))
+	})
+
+	It("should compensate the completed steps in reverse order", func() {
+		cause := errors.New("declined")
+		s := funk.NewSaga[int]("order").
+			Step("reserve", step("reserve", nil), step("release", nil)).
+			Step("notify", step("notify", nil), nil).
+			Step("charge", step("charge", cause), step("refund", nil))
+		_, err := s.Consumer()(funk.WithSagaID(context.Background(), "1"), 1)
+		Expect(performed).To(Equal([]string{"reserve", "notify", "charge", "release"}))
+		Expect(err).To(MatchError(cause))
+		var se *funk.SagaError
+		Expect(errors.As(err, &se)).To(BeTrue())
+		Expect(se.ID).To(Equal("1"))
+		Expect(se.Step).To(Equal("charge"))
+		Expect(se.Compensated()).To(BeTrue())
+		Expect(se.Compensations).To(Equal([]funk.SagaOutcome{{Step: "notify"}, {Step: "reserve"}}))
+		Expect(err.Error()).To(Equal(`saga "order" (1): step "charge" failed: declined; compensated "notify"; compensated "reserve"`))
+	})
+
+	It("should report failed compensations", func() {
+		cause, failure := errors.New("declined"), errors.New("stuck")
+		s := funk.NewSaga[int]("order").
+			Step("reserve", step("reserve", nil), step("release", failure)).
+			Step("charge", step("charge", cause), nil)
+		_, err := s.Consumer()(context.Background(), 1)
+		Expect(err).To(MatchError(cause))
+		Expect(err).To(MatchError(failure))
+		var se *funk.SagaError
+		Expect(errors.As(err, &se)).To(BeTrue())
+		Expect(se.Compensated()).To(BeFalse())
+	})
+
+	It("should panic on duplicated step names", func() {
+		Expect(func() {
+			funk.NewSaga[int]("order").Step("a", step("a", nil), nil).Step("a", step("a", nil), nil)
+		}).To(Panic())
+	})
+
+	Describe("Journal", func() {
+		var path string
+		BeforeEach(func() {
+			path = filepath.Join(GinkgoT().TempDir(), "saga.log")
+		})
+
+		It("should resume compensations of interrupted sagas", func() {
+			j, err := funk.OpenFileJournal(path)
+			Expect(err).To(Not(HaveOccurred()))
+			DeferCleanup(j.Close)
+			for _, r := range []funk.SagaRecord{
+				{Saga: "order", ID: "1", Event: funk.SagaStarted, Arg: []byte("7")},
+				{Saga: "order", ID: "1", Event: funk.SagaStepCompleted, Step: "reserve"},
+				{Saga: "order", ID: "1", Event: funk.SagaStepCompleted, Step: "charge"},
+				{Saga: "order", ID: "1", Event: funk.SagaStepCompensated, Step: "charge"},
+				{Saga: "order", ID: "2", Event: funk.SagaStarted, Arg: []byte("8")},
+				{Saga: "order", ID: "2", Event: funk.SagaFinished},
+				{Saga: "other", ID: "3", Event: funk.SagaStarted, Arg: []byte("9")},
+			} {
+				Expect(j.Append(r)).To(Succeed())
+			}
+			var args []int
+			s := funk.NewSaga[int]("order").WithJournal(j).
+				Step("reserve", step("reserve", nil), func(ctx context.Context, i int) (context.Context, error) {
+					args = append(args, i)
+					return ctx, nil
+				}).
+				Step("charge", step("charge", nil), step("refund", nil))
+			Expect(s.Recover(context.Background())).To(Succeed())
+			Expect(args).To(Equal([]int{7}))
+			Expect(performed).To(BeEmpty())
+
+			Expect(s.Recover(context.Background())).To(Succeed())
+			Expect(args).To(Equal([]int{7}))
+		})
+
+		It("should compensate a reused ID once", func() {
+			j, err := funk.OpenFileJournal(path)
+			Expect(err).To(Not(HaveOccurred()))
+			DeferCleanup(j.Close)
+			for _, r := range []funk.SagaRecord{
+				{Saga: "order", ID: "1", Event: funk.SagaStarted, Arg: []byte("7")},
+				{Saga: "order", ID: "1", Event: funk.SagaStepCompleted, Step: "reserve"},
+				{Saga: "order", ID: "1", Event: funk.SagaFinished},
+				{Saga: "order", ID: "1", Event: funk.SagaStarted, Arg: []byte("7")},
+				{Saga: "order", ID: "1", Event: funk.SagaStepCompleted, Step: "reserve"},
+			} {
+				Expect(j.Append(r)).To(Succeed())
+			}
+			s := funk.NewSaga[int]("order").WithJournal(j).Step("reserve", step("reserve", nil), step("release", nil))
+			Expect(s.Recover(context.Background())).To(Succeed())
+			Expect(performed).To(Equal([]string{"release"}))
+		})
+
+		It("should record the progress and ignore a truncated last line", func() {
+			j, err := funk.OpenFileJournal(path)
+			Expect(err).To(Not(HaveOccurred()))
+			s := funk.NewSaga[int]("order").WithJournal(j).
+				Step("reserve", step("reserve", nil), step("release", nil)).
+				Step("charge", step("charge", errors.New("")), nil)
+			_, err = s.Consumer()(funk.WithSagaID(context.Background(), "1"), 1)
+			Expect(err).To(HaveOccurred())
+			Expect(j.Close()).To(Succeed())
+
+			f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+			Expect(err).To(Not(HaveOccurred()))
+			_, err = f.WriteString(`{"saga":"ord`)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(f.Close()).To(Succeed())
+
+			j, err = funk.OpenFileJournal(path)
+			Expect(err).To(Not(HaveOccurred()))
+			DeferCleanup(j.Close)
+			records, err := j.Records()
+			Expect(err).To(Not(HaveOccurred()))
+			events := make([]funk.SagaEvent, len(records))
+			for i, r := range records {
+				events[i] = r.Event
+			}
+			Expect(events).To(Equal([]funk.SagaEvent{
+				funk.SagaStarted, funk.SagaStepCompleted, funk.SagaStepCompensated, funk.SagaFinished,
+			}))
+		})
+
+		It("should append after a truncated last line", func() {
+			Expect(os.WriteFile(path, []byte(`{"saga":"order","id":"1","event":"started","arg":1}`+"\n"+`{"saga":"ord`), 0o644)).
+				To(Succeed())
+			j, err := funk.OpenFileJournal(path)
+			Expect(err).To(Not(HaveOccurred()))
+			DeferCleanup(j.Close)
+			Expect(j.Append(funk.SagaRecord{Saga: "order", ID: "1", Event: funk.SagaFinished})).To(Succeed())
+			records, err := j.Records()
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(records).To(HaveLen(2))
+			Expect(records[1].Event).To(Equal(funk.SagaFinished))
+			Expect(funk.NewSaga[int]("order").WithJournal(j).Recover(context.Background())).To(Succeed())
+		})
+
+		It("should keep sagas whose compensations fail pending", func() {
+			j, err := funk.OpenFileJournal(path)
+			Expect(err).To(Not(HaveOccurred()))
+			DeferCleanup(j.Close)
+			failing := errors.New("stuck")
+			s := funk.NewSaga[int]("order").WithJournal(j).
+				Step("reserve", step("reserve", nil), func(ctx context.Context, i int) (context.Context, error) {
+					return ctx, failing
+				}).
+				Step("charge", step("charge", errors.New("")), nil)
+			_, err = s.Consumer()(context.Background(), 1)
+			Expect(err).To(MatchError(failing))
+
+			err = s.Recover(context.Background())
+			Expect(err).To(MatchError(failing))
+			Expect(err).To(MatchError(funk.ErrSagaInterrupted))
+
+			failing = nil
+			Expect(s.Recover(context.Background())).To(Succeed())
+			Expect(s.Recover(context.Background())).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,197 @@
+package funk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrUntranslatable is reported when a leaf of Expr can't be translated to SQL.
+var ErrUntranslatable = errors.New("untranslatable leaf")
+
+// SQLDialect represents the hooks of a SQL dialect used by SQLTranslator.
+type SQLDialect struct {
+	// Placeholder returns the placeholder of the nth argument, n starts from 1.
+	Placeholder func(n int) string
+	// QuoteIdent quotes an identifier such as a column name.
+	QuoteIdent func(ident string) string
+	// Collate makes a quoted column compare strings by their bytes as Go does, it's applied to the columns of <, <=,
+	// > and >= comparisons with strings. It may be nil if the columns already compare strings by their bytes.
+	Collate func(column string) string
+}
+
+// QuestionDialect is the SQLDialect with ? placeholders, such as SQLite and MySQL in ANSI mode. It doesn't collate
+// columns, which agrees with the default BINARY collation of SQLite.
+var QuestionDialect = SQLDialect{
+	Placeholder: func(int) string { return "?" },
+	QuoteIdent:  quoteIdent,
+}
+
+// DollarDialect is the SQLDialect with $1, $2, ... placeholders, such as PostgreSQL. It collates the columns of
+// ordered string comparisons with the "C" collation.
+var DollarDialect = SQLDialect{
+	Placeholder: func(n int) string { return "$" + strconv.Itoa(n) },
+	QuoteIdent:  quoteIdent,
+	Collate:     func(column string) string { return column + ` COLLATE "C"` },
+}
+
+func quoteIdent(ident string) string {
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
+
+// SQLLeafTranslator translates the parameters of a leaf to a SQL condition, arg adds an argument and returns its
+// placeholder.
+type SQLLeafTranslator func(params json.RawMessage, arg func(v any) string) (string, error)
+
+// SQLTranslator translates Expr to parameterized SQL WHERE conditions.
+// Comparison leaves are translated to conditions on the columns named after their fields, other leaves are
+// translated by the registered SQLLeafTranslator.
+//
+// SQL conditions agree with the predicates compiled from the same Expr as long as the columns are NOT NULL, since
+// any comparison with NULL is unknown in SQL, and the columns compare equal strings by their bytes, which isn't the
+// case for the case-insensitive collations of MySQL. Ordered string comparisons are collated by the dialect.
+type SQLTranslator struct {
+	dialect SQLDialect
+	columns map[string]string
+	leaves  map[string]SQLLeafTranslator
+}
+
+// NewSQLTranslator returns a SQLTranslator of dialect.
+func NewSQLTranslator(dialect SQLDialect) *SQLTranslator {
+	return &SQLTranslator{dialect: dialect, columns: map[string]string{}, leaves: map[string]SQLLeafTranslator{}}
+}
+
+// sqlIdentPattern matches the fields that are used as column names if they are not mapped by Column.
+var sqlIdentPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Column maps field of comparison leaves to column. Unmapped fields are used as column names only if they are plain
+// identifiers, so the fields of untrusted expressions can't inject arbitrary identifiers.
+func (t *SQLTranslator) Column(field, column string) *SQLTranslator {
+	t.columns[field] = column
+	return t
+}
+
+// RegisterLeaf registers translator for the leaves named name, it panics if name is already registered.
+func (t *SQLTranslator) RegisterLeaf(name string, translator SQLLeafTranslator) *SQLTranslator {
+	if _, ok := t.leaves[name]; ok || name == LeafCompare {
+		panic(fmt.Sprintf("funk: SQL leaf translator %q is already registered", name))
+	}
+	t.leaves[name] = translator
+	return t
+}
+
+// Translate translates e to a SQL condition and its arguments.
+// Errors of malformed nodes and untranslatable leaves are reported as *ExprError, the latter wraps
+// ErrUntranslatable.
+func (t *SQLTranslator) Translate(e *Expr) (string, []any, error) {
+	if err := e.Validate(); err != nil {
+		return "", nil, err
+	}
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return t.dialect.Placeholder(len(args))
+	}
+	where, err := t.translate(e, "$", arg)
+	if err != nil {
+		return "", nil, err
+	}
+	return where, args, nil
+}
+
+func (t *SQLTranslator) translate(e *Expr, path string, arg func(any) string) (string, error) {
+	switch e.Op {
+	case OpLeaf:
+		var where string
+		var err error
+		if e.Name == LeafCompare {
+			where, err = t.compare(e.Params, arg)
+		} else if translator, ok := t.leaves[e.Name]; ok {
+			where, err = translator(e.Params, arg)
+		} else {
+			err = fmt.Errorf("%w %q", ErrUntranslatable, e.Name)
+		}
+		if err != nil {
+			return "", &ExprError{Path: path, Err: err}
+		}
+		return where, nil
+	case OpNot:
+		where, err := t.translate(e.Operands[0], path+".operands[0]", arg)
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + where + ")", nil
+	}
+
+	if len(e.Operands) == 0 {
+		if e.Op == OpAnd {
+			return "1 = 1", nil
+		}
+		return "1 = 0", nil
+	}
+	conditions := make([]string, len(e.Operands))
+	for i, o := range e.Operands {
+		where, err := t.translate(o, fmt.Sprintf("%s.operands[%d]", path, i), arg)
+		if err != nil {
+			return "", err
+		}
+		conditions[i] = where
+	}
+	sep := " AND "
+	if e.Op == OpOr {
+		sep = " OR "
+	}
+	return "(" + strings.Join(conditions, sep) + ")", nil
+}
+
+func (t *SQLTranslator) compare(params json.RawMessage, arg func(any) string) (string, error) {
+	c, literals, err := decodeComparison(params)
+	if err != nil {
+		return "", err
+	}
+	column, ok := t.columns[c.Field]
+	if !ok {
+		if !sqlIdentPattern.MatchString(c.Field) {
+			return "", fmt.Errorf("%w: field %q isn't mapped to a column and isn't a plain identifier",
+				ErrUntranslatable, c.Field)
+		}
+		column = c.Field
+	}
+	column = t.dialect.QuoteIdent(column)
+
+	values := make([]any, len(literals))
+	for i, l := range literals {
+		values[i] = l
+		if n, ok := l.(json.Number); ok {
+			if values[i], err = n.Int64(); err != nil {
+				if values[i], err = n.Float64(); err != nil {
+					return "", fmt.Errorf("invalid number %s", n)
+				}
+			}
+		}
+	}
+
+	switch c.Op {
+	case "in":
+		if len(values) == 0 {
+			return "1 = 0", nil
+		}
+		placeholders := make([]string, len(values))
+		for i, v := range values {
+			placeholders[i] = arg(v)
+		}
+		return column + " IN (" + strings.Join(placeholders, ", ") + ")", nil
+	case "==":
+		return column + " = " + arg(values[0]), nil
+	case "!=":
+		return column + " <> " + arg(values[0]), nil
+	default:
+		if _, ok := values[0].(string); ok && t.dialect.Collate != nil {
+			column = t.dialect.Collate(column)
+		}
+		return column + " " + c.Op + " " + arg(values[0]), nil
+	}
+}
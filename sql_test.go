@@ -0,0 +1,89 @@
+package funk_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Translating predicates to SQL", func() {
+	var t *funk.SQLTranslator
+	BeforeEach(func() {
+		t = funk.NewSQLTranslator(funk.DollarDialect).Column("vip", "is_vip")
+	})
+
+	It("should translate comparisons and logical operations", func() {
+		where, args, err := t.Translate(funk.ExprAnd(
+			funk.ExprCompare("age", ">=", 18),
+			funk.ExprOr(funk.ExprIn("country", "DE", "FR"), funk.ExprCompare("vip", "==", true)),
+			funk.ExprNot(funk.ExprCompare("score", "!=", 1.5)),
+		))
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(where).To(Equal(`("age" >= $1 AND ("country" IN ($2, $3) OR "is_vip" = $4) AND NOT ("score" <> $5))`))
+		Expect(args).To(Equal([]any{int64(18), "DE", "FR", true, 1.5}))
+	})
+
+	It("should translate empty groups", func() {
+		where, args, err := t.Translate(funk.ExprOr(funk.ExprAnd(), funk.ExprIn("age")))
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(where).To(Equal("(1 = 1 OR 1 = 0)"))
+		Expect(args).To(BeEmpty())
+	})
+
+	It("should use the placeholders of dialect", func() {
+		where, _, err := funk.NewSQLTranslator(funk.QuestionDialect).Column("ab", `a"b`).Translate(funk.ExprIn("ab", 1, 2))
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(where).To(Equal(`"a""b" IN (?, ?)`))
+	})
+
+	It("should collate ordered string comparisons", func() {
+		where, args, err := t.Translate(funk.ExprAnd(
+			funk.ExprCompare("country", "<", "FR"),
+			funk.ExprCompare("country", "==", "DE"),
+			funk.ExprCompare("age", "<", 18),
+		))
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(where).To(Equal(`("country" COLLATE "C" < $1 AND "country" = $2 AND "age" < $3)`))
+		Expect(args).To(Equal([]any{"FR", "DE", int64(18)}))
+
+		where, _, err = funk.NewSQLTranslator(funk.QuestionDialect).Translate(funk.ExprCompare("country", "<", "FR"))
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(where).To(Equal(`"country" < ?`))
+	})
+
+	It("should translate registered leaves", func() {
+		t.RegisterLeaf("adult", func(params json.RawMessage, arg func(any) string) (string, error) {
+			return fmt.Sprintf(`"age" >= %s`, arg(18)), nil
+		})
+		where, args, err := t.Translate(funk.ExprNot(funk.ExprLeaf("adult", nil)))
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(where).To(Equal(`NOT ("age" >= $1)`))
+		Expect(args).To(Equal([]any{18}))
+	})
+
+	It("should report untranslatable leaves", func() {
+		_, _, err := t.Translate(funk.ExprAnd(funk.ExprCompare("age", ">", 1), funk.ExprLeaf("remote", nil)))
+		Expect(errors.Is(err, funk.ErrUntranslatable)).To(BeTrue())
+		var exprErr *funk.ExprError
+		Expect(errors.As(err, &exprErr)).To(BeTrue())
+		Expect(exprErr.Path).To(Equal("$.operands[1]"))
+	})
+
+	It("should reject unmapped fields that are not plain identifiers", func() {
+		_, _, err := t.Translate(funk.ExprCompare(`age" OR 1 = 1 --`, ">", 1))
+		Expect(errors.Is(err, funk.ErrUntranslatable)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("isn't mapped to a column")))
+	})
+
+	It("should report malformed comparisons", func() {
+		_, _, err := t.Translate(funk.ExprCompare("age", "~", 1))
+		Expect(err).To(MatchError(`$: unknown comparison operator "~"`))
+		_, _, err = t.Translate(funk.ExprCompare("age", "in", 1))
+		Expect(err).To(MatchError(`$: operator "in" requires a list of values`))
+	})
+})
@@ -0,0 +1,4 @@
+// Package sqlitetest checks that the SQL conditions translated by funk.SQLTranslator agree with the predicates
+// compiled from the same expressions, using SQLite. It's a separate module, so the library doesn't depend on the cgo
+// driver.
+package sqlitetest
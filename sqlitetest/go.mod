@@ -0,0 +1,24 @@
+module github.com/hongcankun/gofunk/sqlitetest
+
+go 1.21
+
+replace github.com/hongcankun/gofunk => ../
+
+require (
+	github.com/hongcankun/gofunk v0.0.0-00010101000000-000000000000
+	github.com/mattn/go-sqlite3 v1.14.33
+	github.com/onsi/ginkgo/v2 v2.13.1
+	github.com/onsi/gomega v1.30.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-task/slim-sprig v0.0.0-20230315185526-52ccab3ef572 // indirect
+	github.com/google/go-cmp v0.6.0 // indirect
+	github.com/google/pprof v0.0.0-20210407192527-94a9f03dee38 // indirect
+	golang.org/x/net v0.17.0 // indirect
+	golang.org/x/sys v0.14.0 // indirect
+	golang.org/x/text v0.13.0 // indirect
+	golang.org/x/tools v0.14.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
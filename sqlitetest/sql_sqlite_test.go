@@ -0,0 +1,95 @@
+//go:build cgo
+
+package sqlitetest_test
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/mattn/go-sqlite3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Evaluating predicates in memory and in SQLite", func() {
+	type customer struct {
+		ID      int
+		Age     int
+		Country string
+		VIP     bool
+		Score   float64
+	}
+	customers := []customer{
+		{1, 17, "DE", false, 1.5},
+		{2, 18, "FR", false, 4.5},
+		{3, 30, "US", true, 3},
+		{4, 65, "DE", true, 5},
+		{5, 40, "de", false, 0},
+	}
+
+	var db *sql.DB
+	BeforeEach(func() {
+		var err error
+		db, err = sql.Open("sqlite3", ":memory:")
+		Expect(err).To(Not(HaveOccurred()))
+		DeferCleanup(db.Close)
+		_, err = db.Exec(`CREATE TABLE customers (
+			id INTEGER PRIMARY KEY, age INTEGER NOT NULL, country TEXT NOT NULL, is_vip BOOLEAN NOT NULL,
+			score REAL NOT NULL)`)
+		Expect(err).To(Not(HaveOccurred()))
+		for _, c := range customers {
+			_, err = db.Exec(`INSERT INTO customers VALUES (?, ?, ?, ?, ?)`, c.ID, c.Age, c.Country, c.VIP, c.Score)
+			Expect(err).To(Not(HaveOccurred()))
+		}
+	})
+
+	DescribeTable("agreeing",
+		func(e *funk.Expr) {
+			r := funk.NewPredicateRegistry[customer]().
+				Register(funk.LeafCompare, funk.NewPredicateParser[customer]().CompareFactory())
+			p, err := r.Compile(e)
+			Expect(err).To(Not(HaveOccurred()))
+			var inMemory []int
+			for _, c := range customers {
+				_, v, err := p(context.Background(), c)
+				Expect(err).To(Not(HaveOccurred()))
+				if v {
+					inMemory = append(inMemory, c.ID)
+				}
+			}
+
+			where, args, err := funk.NewSQLTranslator(funk.QuestionDialect).Column("vip", "is_vip").Translate(e)
+			Expect(err).To(Not(HaveOccurred()))
+			rows, err := db.Query(`SELECT id FROM customers WHERE `+where+` ORDER BY id`, args...)
+			Expect(err).To(Not(HaveOccurred()))
+			defer rows.Close()
+			var inSQL []int
+			for rows.Next() {
+				var id int
+				Expect(rows.Scan(&id)).To(Succeed())
+				inSQL = append(inSQL, id)
+			}
+			Expect(rows.Err()).To(Not(HaveOccurred()))
+
+			Expect(inSQL).To(Equal(inMemory))
+		},
+		Entry("comparison", funk.ExprCompare("age", ">=", 18)),
+		Entry("string ordering", funk.ExprCompare("country", "<", "FR")),
+		Entry("case-sensitive equality", funk.ExprCompare("country", "==", "DE")),
+		Entry("bool", funk.ExprCompare("vip", "==", true)),
+		Entry("float", funk.ExprCompare("score", "<=", 3)),
+		Entry("membership", funk.ExprIn("country", "DE", "US")),
+		Entry("empty membership", funk.ExprIn("age")),
+		Entry("composition", funk.ExprAnd(
+			funk.ExprCompare("age", ">=", 18),
+			funk.ExprOr(funk.ExprIn("country", "DE", "FR"), funk.ExprCompare("vip", "==", true)),
+		)),
+		Entry("negation", funk.ExprNot(funk.ExprOr(
+			funk.ExprCompare("age", "!=", 30),
+			funk.ExprNot(funk.ExprCompare("vip", "!=", false)),
+		))),
+		Entry("empty groups", funk.ExprOr(funk.ExprAnd(), funk.ExprOr())),
+	)
+})
@@ -0,0 +1,15 @@
+//go:build cgo
+
+package sqlitetest_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSqlitetest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Sqlitetest Suite")
+}
@@ -0,0 +1,259 @@
+package funk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// StageError is returned by stages, it reports the innermost stage that failed.
+type StageError struct {
+	// Name is the name of the failed stage.
+	Name string
+	// Path is the names of the enclosing stages and the failed stage joined by "/", e.g. ingest/normalize/validate.
+	Path string
+	// Err is the error returned by the failed stage.
+	Err error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("stage %s: %v", e.Path, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+type stagePathKey struct{}
+
+// StagePath returns the path of the named stage performed with ctx, it's empty outside named stages.
+func StagePath(ctx context.Context) string {
+	path, _ := ctx.Value(stagePathKey{}).(string)
+	return path
+}
+
+// stage performs f as a stage named name nested in the stage of ctx. The returned context is restored to the path of
+// the enclosing stage, so the stages composed after it are not nested in it.
+// Panics are not recovered, so they keep their values and stacks.
+func stage[R any](ctx context.Context, name string, f func(context.Context) (context.Context, R, error)) (context.Context, R, error) {
+	parent := StagePath(ctx)
+	path := name
+	if parent != "" {
+		path = parent + "/" + name
+	}
+	ctx, r, err := f(context.WithValue(ctx, stagePathKey{}, path))
+	if ctx != nil && StagePath(ctx) != parent {
+		ctx = context.WithValue(ctx, stagePathKey{}, parent)
+	}
+	if err != nil {
+		err = wrapStage(name, path, err)
+	}
+	return ctx, r, err
+}
+
+// wrapStage wraps err as StageError unless it's already wrapped by an inner stage.
+func wrapStage(name, path string, err error) error {
+	var se *StageError
+	if errors.As(err, &se) {
+		return err
+	}
+	return &StageError{Name: name, Path: path, Err: err}
+}
+
+func stageConsumer(ctx context.Context, name string, f func(context.Context) (context.Context, error)) (context.Context, error) {
+	ctx, _, err := stage(ctx, name, func(ctx context.Context) (context.Context, struct{}, error) {
+		ctx, err := f(ctx)
+		return ctx, struct{}{}, err
+	})
+	return ctx, err
+}
+
+// Stage returns a Func that performs as a stage named name, its error is wrapped as StageError.
+func (f Func[T, R]) Stage(name string) Func[T, R] {
+	return func(ctx context.Context, t T) (context.Context, R, error) {
+		return stage(ctx, name, func(ctx context.Context) (context.Context, R, error) {
+			return f(ctx, t)
+		})
+	}
+}
+
+// Stage returns a MustFunc that performs as a stage named name, its panics are not wrapped.
+func (f MustFunc[T, R]) Stage(name string) MustFunc[T, R] {
+	return f.Lift().Stage(name).Must()
+}
+
+// Stage returns a PureFunc that performs as a stage named name, its error is wrapped as StageError.
+func (f PureFunc[T, R]) Stage(name string) PureFunc[T, R] {
+	return f.Lift().Stage(name).Pure()
+}
+
+// Stage returns a PureMustFunc that performs as a stage named name, its panics are not wrapped.
+func (f PureMustFunc[T, R]) Stage(name string) PureMustFunc[T, R] {
+	return f.Lift().Stage(name).Must().Pure()
+}
+
+// Stage returns a Unary that performs as a stage named name, its error is wrapped as StageError.
+func (u Unary[T]) Stage(name string) Unary[T] {
+	return Unary[T](Func[T, T](u).Stage(name))
+}
+
+// Stage returns a MustUnary that performs as a stage named name, its panics are not wrapped.
+func (u MustUnary[T]) Stage(name string) MustUnary[T] {
+	return u.Lift().Stage(name).Must()
+}
+
+// Stage returns a PureUnary that performs as a stage named name, its error is wrapped as StageError.
+func (u PureUnary[T]) Stage(name string) PureUnary[T] {
+	return u.Lift().Stage(name).Pure()
+}
+
+// Stage returns a PureMustUnary that performs as a stage named name, its panics are not wrapped.
+func (u PureMustUnary[T]) Stage(name string) PureMustUnary[T] {
+	return u.Lift().Stage(name).Must().Pure()
+}
+
+// Stage returns a BiFunc that performs as a stage named name, its error is wrapped as StageError.
+func (f BiFunc[T, U, R]) Stage(name string) BiFunc[T, U, R] {
+	return func(ctx context.Context, t T, u U) (context.Context, R, error) {
+		return stage(ctx, name, func(ctx context.Context) (context.Context, R, error) {
+			return f(ctx, t, u)
+		})
+	}
+}
+
+// Stage returns a MustBiFunc that performs as a stage named name, its panics are not wrapped.
+func (f MustBiFunc[T, U, R]) Stage(name string) MustBiFunc[T, U, R] {
+	return f.Lift().Stage(name).Must()
+}
+
+// Stage returns a PureBiFunc that performs as a stage named name, its error is wrapped as StageError.
+func (f PureBiFunc[T, U, R]) Stage(name string) PureBiFunc[T, U, R] {
+	return f.Lift().Stage(name).Pure()
+}
+
+// Stage returns a PureMustBiFunc that performs as a stage named name, its panics are not wrapped.
+func (f PureMustBiFunc[T, U, R]) Stage(name string) PureMustBiFunc[T, U, R] {
+	return f.Lift().Stage(name).Must().Pure()
+}
+
+// Stage returns a Supplier that performs as a stage named name, its error is wrapped as StageError.
+func (s Supplier[T]) Stage(name string) Supplier[T] {
+	return func(ctx context.Context) (context.Context, T, error) {
+		return stage(ctx, name, s)
+	}
+}
+
+// Stage returns a MustSupplier that performs as a stage named name, its panics are not wrapped.
+func (c MustSupplier[T]) Stage(name string) MustSupplier[T] {
+	return c.Lift().Stage(name).Must()
+}
+
+// Stage returns a PureSupplier that performs as a stage named name, its error is wrapped as StageError.
+func (c PureSupplier[T]) Stage(name string) PureSupplier[T] {
+	return c.Lift().Stage(name).Pure()
+}
+
+// Stage returns a PureMustSupplier that performs as a stage named name, its panics are not wrapped.
+func (c PureMustSupplier[T]) Stage(name string) PureMustSupplier[T] {
+	return c.Lift().Stage(name).Must().Pure()
+}
+
+// Stage returns a Consumer that performs as a stage named name, its error is wrapped as StageError.
+func (c Consumer[T]) Stage(name string) Consumer[T] {
+	return func(ctx context.Context, t T) (context.Context, error) {
+		return stageConsumer(ctx, name, func(ctx context.Context) (context.Context, error) {
+			return c(ctx, t)
+		})
+	}
+}
+
+// Stage returns a MustConsumer that performs as a stage named name, its panics are not wrapped.
+func (c MustConsumer[T]) Stage(name string) MustConsumer[T] {
+	return c.Lift().Stage(name).Must()
+}
+
+// Stage returns a PureConsumer that performs as a stage named name, its error is wrapped as StageError.
+func (c PureConsumer[T]) Stage(name string) PureConsumer[T] {
+	return c.Lift().Stage(name).Pure()
+}
+
+// Stage returns a PureMustConsumer that performs as a stage named name, its panics are not wrapped.
+func (c PureMustConsumer[T]) Stage(name string) PureMustConsumer[T] {
+	return c.Lift().Stage(name).Must().Pure()
+}
+
+// Stage returns a BiConsumer that performs as a stage named name, its error is wrapped as StageError.
+func (c BiConsumer[T, U]) Stage(name string) BiConsumer[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, error) {
+		return stageConsumer(ctx, name, func(ctx context.Context) (context.Context, error) {
+			return c(ctx, t, u)
+		})
+	}
+}
+
+// Stage returns a MustBiConsumer that performs as a stage named name, its panics are not wrapped.
+func (c MustBiConsumer[T, U]) Stage(name string) MustBiConsumer[T, U] {
+	return c.Lift().Stage(name).Must()
+}
+
+// Stage returns a PureBiConsumer that performs as a stage named name, its error is wrapped as StageError.
+func (c PureBiConsumer[T, U]) Stage(name string) PureBiConsumer[T, U] {
+	return c.Lift().Stage(name).Pure()
+}
+
+// Stage returns a PureMustBiConsumer that performs as a stage named name, its panics are not wrapped.
+func (c PureMustBiConsumer[T, U]) Stage(name string) PureMustBiConsumer[T, U] {
+	return c.Lift().Stage(name).Must().Pure()
+}
+
+// Stage returns a Predicate that is reported with name when explained and performs as a stage named name, its error
+// is wrapped as StageError.
+func (p Predicate[T]) Stage(name string) Predicate[T] {
+	return func(ctx context.Context, t T) (context.Context, bool, error) {
+		return explainNode(ctx, name, func(ctx context.Context) (context.Context, bool, error) {
+			return stage(ctx, name, p.bind(t))
+		})
+	}
+}
+
+// Stage returns a MustPredicate that is reported with name when explained and performs as a stage named name, its
+// panics are not wrapped.
+func (p MustPredicate[T]) Stage(name string) MustPredicate[T] {
+	return p.Lift().Stage(name).Must()
+}
+
+// Stage returns a BiPredicate that is reported with name when explained and performs as a stage named name, its
+// error is wrapped as StageError.
+func (p BiPredicate[T, U]) Stage(name string) BiPredicate[T, U] {
+	return func(ctx context.Context, t T, u U) (context.Context, bool, error) {
+		return explainNode(ctx, name, func(ctx context.Context) (context.Context, bool, error) {
+			return stage(ctx, name, p.bind(t, u))
+		})
+	}
+}
+
+// Stage returns a MustBiPredicate that is reported with name when explained and performs as a stage named name, its
+// panics are not wrapped.
+func (p MustBiPredicate[T, U]) Stage(name string) MustBiPredicate[T, U] {
+	return p.Lift().Stage(name).Must()
+}
+
+// Stage returns a PurePredicate that performs as a stage named name, its error is wrapped as StageError.
+func (p PurePredicate[T]) Stage(name string) PurePredicate[T] {
+	return p.Lift().Stage(name).Pure()
+}
+
+// Stage returns a PureMustPredicate that performs as a stage named name, its panics are not wrapped.
+func (p PureMustPredicate[T]) Stage(name string) PureMustPredicate[T] {
+	return p.Lift().Stage(name).Must().Pure()
+}
+
+// Stage returns a PureBiPredicate that performs as a stage named name, its error is wrapped as StageError.
+func (p PureBiPredicate[T, U]) Stage(name string) PureBiPredicate[T, U] {
+	return p.Lift().Stage(name).Pure()
+}
+
+// Stage returns a PureMustBiPredicate that performs as a stage named name, its panics are not wrapped.
+func (p PureMustBiPredicate[T, U]) Stage(name string) PureMustBiPredicate[T, U] {
+	return p.Lift().Stage(name).Must().Pure()
+}
@@ -0,0 +1,109 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Stages", func() {
+	var paths []string
+	stage := func(err error) funk.Unary[int] {
+		return func(ctx context.Context, i int) (context.Context, int, error) {
+			paths = append(paths, funk.StagePath(ctx))
+			return incCtxValue(ctx), i + 1, err
+		}
+	}
+	BeforeEach(func() {
+		paths = nil
+	})
+
+	It("should wrap errors as StageError", func() {
+		cause := errors.New("boom")
+		_, _, err := stage(cause).Stage("validate")(context.Background(), 1)
+		Expect(err).To(MatchError(cause))
+		Expect(err).To(MatchError("stage validate: boom"))
+		var se *funk.StageError
+		Expect(errors.As(err, &se)).To(BeTrue())
+		Expect(se.Name).To(Equal("validate"))
+		Expect(se.Path).To(Equal("validate"))
+	})
+
+	It("should build paths of nested stages", func() {
+		cause := errors.New("boom")
+		normalize := stage(nil).Stage("trim").Then(stage(nil).Stage("lower")).Stage("normalize")
+		u := normalize.Then(stage(cause).Stage("validate")).Stage("ingest")
+		ctx, _, err := u(context.Background(), 1)
+		Expect(paths).To(Equal([]string{"ingest/normalize/trim", "ingest/normalize/lower", "ingest/validate"}))
+		var se *funk.StageError
+		Expect(errors.As(err, &se)).To(BeTrue())
+		Expect(se.Name).To(Equal("validate"))
+		Expect(se.Path).To(Equal("ingest/validate"))
+		Expect(err).To(MatchError("stage ingest/validate: boom"))
+		Expect(funk.StagePath(ctx)).To(BeEmpty())
+		Expect(getCtxValue(ctx)).To(Equal(3))
+	})
+
+	It("should name consumers", func() {
+		c := funk.Consumer[int](func(ctx context.Context, i int) (context.Context, error) {
+			paths = append(paths, funk.StagePath(ctx))
+			return ctx, errors.New("")
+		})
+		_, err := c.Stage("send").Then(c).Stage("notify")(context.Background(), 1)
+		Expect(paths).To(Equal([]string{"notify/send"}))
+		var se *funk.StageError
+		Expect(errors.As(err, &se)).To(BeTrue())
+		Expect(se.Path).To(Equal("notify/send"))
+	})
+
+	It("should name predicates", func() {
+		p := funk.Predicate[int](func(ctx context.Context, i int) (context.Context, bool, error) {
+			return ctx, false, errors.New("")
+		})
+		_, _, err := funk.AllOf(funk.Gt(0).Lift(), p.Stage("check")).Stage("rules")(context.Background(), 1)
+		var se *funk.StageError
+		Expect(errors.As(err, &se)).To(BeTrue())
+		Expect(se.Path).To(Equal("rules/check"))
+
+		_, e := p.Stage("check").Explain(context.Background(), 1)
+		Expect(e.Name).To(Equal("check"))
+	})
+
+	It("should not be implied by naming predicates for explanations", func() {
+		cause := errors.New("boom")
+		p := funk.Predicate[int](func(ctx context.Context, i int) (context.Context, bool, error) {
+			Expect(funk.StagePath(ctx)).To(BeEmpty())
+			return ctx, false, cause
+		})
+		_, _, err := p.Named("check")(context.Background(), 1)
+		Expect(err).To(Equal(cause))
+	})
+
+	It("should wrap errors of pure variants", func() {
+		_, err := funk.PureSupplier[int](func() (int, error) {
+			return 0, errors.New("boom")
+		}).Stage("load")()
+		Expect(err).To(MatchError("stage load: boom"))
+	})
+
+	It("should panic with StageError if converted to must variants", func() {
+		c := funk.BiConsumer[int, int](func(ctx context.Context, a, b int) (context.Context, error) {
+			return ctx, errors.New("boom")
+		}).Stage("sum").Must()
+		Expect(func() { c(context.Background(), 1, 2) }).To(PanicWith(MatchError("stage sum: boom")))
+	})
+
+	It("should not wrap panics", func() {
+		var s []int
+		f := funk.PureMustFunc[int, int](func(i int) int { return s[i] }).Stage("index")
+		Expect(func() { f(1) }).To(PanicWith(Satisfy(func(v any) bool {
+			_, ok := v.(runtime.Error)
+			return ok
+		})))
+	})
+})
@@ -51,3 +51,26 @@ func (c PureSupplier[T]) Must() PureMustSupplier[T] {
 		return v
 	}
 }
+
+// Lift returns a Supplier that passes the context through and never returns error.
+func (c MustSupplier[T]) Lift() Supplier[T] {
+	return func(ctx context.Context) (context.Context, T, error) {
+		ctx, v := c(ctx)
+		return ctx, v, nil
+	}
+}
+
+// Lift returns a Supplier that passes the context through untouched.
+func (c PureSupplier[T]) Lift() Supplier[T] {
+	return func(ctx context.Context) (context.Context, T, error) {
+		v, err := c()
+		return ctx, v, err
+	}
+}
+
+// Lift returns a Supplier that passes the context through untouched and never returns error.
+func (c PureMustSupplier[T]) Lift() Supplier[T] {
+	return func(ctx context.Context) (context.Context, T, error) {
+		return ctx, c(), nil
+	}
+}
@@ -0,0 +1,279 @@
+package funk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrNoSupplier is returned by FirstSuccessful when it has no suppliers to try.
+var ErrNoSupplier = errors.New("no supplier")
+
+// Pair represents two values supplied together.
+type Pair[T, U any] struct {
+	First  T
+	Second U
+}
+
+// MapSupplier returns a Supplier that applies f to the result of s, the context returned by s is passed to f.
+func MapSupplier[T, R any](s Supplier[T], f Func[T, R]) Supplier[R] {
+	return func(ctx context.Context) (context.Context, R, error) {
+		ctx, t, err := s(ctx)
+		if err != nil {
+			var r R
+			return ctx, r, err
+		}
+		return f(ctx, t)
+	}
+}
+
+// ZipSuppliers returns a Supplier that performs first and then second, and supplies both results as a Pair.
+// The context returned by first is passed to second, and second is not performed if first returns error.
+func ZipSuppliers[T, U any](first Supplier[T], second Supplier[U]) Supplier[Pair[T, U]] {
+	return func(ctx context.Context) (context.Context, Pair[T, U], error) {
+		var p Pair[T, U]
+		ctx, t, err := first(ctx)
+		if err != nil {
+			return ctx, p, err
+		}
+		ctx, u, err := second(ctx)
+		if err != nil {
+			return ctx, p, err
+		}
+		p.First, p.Second = t, u
+		return ctx, p, nil
+	}
+}
+
+// FirstSuccessful returns a Supplier that performs ss in order until one of them succeeds, threading the context
+// through each attempt. If all of them fail, it returns the joined errors, and ErrNoSupplier if ss is empty.
+func FirstSuccessful[T any](ss ...Supplier[T]) Supplier[T] {
+	return func(ctx context.Context) (context.Context, T, error) {
+		var t T
+		if len(ss) == 0 {
+			return ctx, t, ErrNoSupplier
+		}
+		errs := make([]error, 0, len(ss))
+		for _, s := range ss {
+			var err error
+			if ctx, t, err = s(ctx); err == nil {
+				return ctx, t, nil
+			}
+			errs = append(errs, err)
+		}
+		var zero T
+		return ctx, zero, errors.Join(errs...)
+	}
+}
+
+// OrElse returns a Supplier that supplies v instead of returning the error of this supplier.
+func (s Supplier[T]) OrElse(v T) Supplier[T] {
+	return func(ctx context.Context) (context.Context, T, error) {
+		ctx, t, err := s(ctx)
+		if err != nil {
+			return ctx, v, nil
+		}
+		return ctx, t, nil
+	}
+}
+
+// Iterate returns a Supplier of the infinite sequence seed, u(seed), u(u(seed)) and so on, each call supplies the
+// next element. The sequence doesn't advance if u returns error. It's safe for concurrent use, and u is never
+// applied concurrently.
+func Iterate[T any](seed T, u Unary[T]) Supplier[T] {
+	var mu sync.Mutex
+	started := false
+	return func(ctx context.Context) (context.Context, T, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !started {
+			started = true
+			return ctx, seed, nil
+		}
+		ctx, v, err := u(ctx, seed)
+		if err != nil {
+			return ctx, v, err
+		}
+		seed = v
+		return ctx, v, nil
+	}
+}
+
+// Generate returns a Supplier of the infinite sequence f(0), f(1), f(2) and so on, each call supplies the next
+// element. The index advances on every call, even if f returns error. It's safe for concurrent use.
+func Generate[T any](f Func[int, T]) Supplier[T] {
+	var i atomic.Int64
+	return func(ctx context.Context) (context.Context, T, error) {
+		return f(ctx, int(i.Add(1)-1))
+	}
+}
+
+// Take returns a Supplier that supplies the next n results of s as a slice, threading the context through each call.
+// It stops on the first error and supplies the results taken so far.
+func Take[T any](s Supplier[T], n int) Supplier[[]T] {
+	return func(ctx context.Context) (context.Context, []T, error) {
+		ts := make([]T, 0, max(n, 0))
+		for len(ts) < n {
+			ctx2, t, err := s(ctx)
+			ctx = ctx2
+			if err != nil {
+				return ctx, ts, err
+			}
+			ts = append(ts, t)
+		}
+		return ctx, ts, nil
+	}
+}
+
+// Cycle returns a Supplier that supplies the elements of vs in order, starting over after the last one.
+// It's safe for concurrent use, and it panics if vs is empty.
+func Cycle[T any](vs ...T) Supplier[T] {
+	if len(vs) == 0 {
+		panic("funk: Cycle requires at least one value")
+	}
+	vs = append([]T(nil), vs...)
+	var i atomic.Uint64
+	return func(ctx context.Context) (context.Context, T, error) {
+		return ctx, vs[(i.Add(1)-1)%uint64(len(vs))], nil
+	}
+}
+
+// Constant returns a Supplier that always supplies v.
+func Constant[T any](v T) Supplier[T] {
+	return func(ctx context.Context) (context.Context, T, error) {
+		return ctx, v, nil
+	}
+}
+
+// Failing returns a Supplier that always returns err.
+func Failing[T any](err error) Supplier[T] {
+	return func(ctx context.Context) (context.Context, T, error) {
+		var t T
+		return ctx, t, err
+	}
+}
+
+// MustMapSupplier is MapSupplier for MustSupplier.
+func MustMapSupplier[T, R any](s MustSupplier[T], f MustFunc[T, R]) MustSupplier[R] {
+	return MapSupplier(s.Lift(), f.Lift()).Must()
+}
+
+// MustZipSuppliers is ZipSuppliers for MustSupplier.
+func MustZipSuppliers[T, U any](first MustSupplier[T], second MustSupplier[U]) MustSupplier[Pair[T, U]] {
+	return ZipSuppliers(first.Lift(), second.Lift()).Must()
+}
+
+// MustIterate is Iterate for MustUnary.
+func MustIterate[T any](seed T, u MustUnary[T]) MustSupplier[T] {
+	return Iterate(seed, u.Lift()).Must()
+}
+
+// MustGenerate is Generate for MustFunc.
+func MustGenerate[T any](f MustFunc[int, T]) MustSupplier[T] {
+	return Generate(f.Lift()).Must()
+}
+
+// MustTake is Take for MustSupplier.
+func MustTake[T any](s MustSupplier[T], n int) MustSupplier[[]T] {
+	return Take(s.Lift(), n).Must()
+}
+
+// MustCycle is Cycle for MustSupplier.
+func MustCycle[T any](vs ...T) MustSupplier[T] {
+	return Cycle(vs...).Must()
+}
+
+// MustConstant is Constant for MustSupplier.
+func MustConstant[T any](v T) MustSupplier[T] {
+	return Constant(v).Must()
+}
+
+// PureMapSupplier is MapSupplier for PureSupplier.
+func PureMapSupplier[T, R any](s PureSupplier[T], f PureFunc[T, R]) PureSupplier[R] {
+	return MapSupplier(s.Lift(), f.Lift()).Pure()
+}
+
+// PureZipSuppliers is ZipSuppliers for PureSupplier.
+func PureZipSuppliers[T, U any](first PureSupplier[T], second PureSupplier[U]) PureSupplier[Pair[T, U]] {
+	return ZipSuppliers(first.Lift(), second.Lift()).Pure()
+}
+
+// PureFirstSuccessful is FirstSuccessful for PureSupplier.
+func PureFirstSuccessful[T any](ss ...PureSupplier[T]) PureSupplier[T] {
+	lifted := make([]Supplier[T], len(ss))
+	for i, s := range ss {
+		lifted[i] = s.Lift()
+	}
+	return FirstSuccessful(lifted...).Pure()
+}
+
+// OrElse returns a PureSupplier that supplies v instead of returning the error of this supplier.
+func (c PureSupplier[T]) OrElse(v T) PureSupplier[T] {
+	return c.Lift().OrElse(v).Pure()
+}
+
+// PureIterate is Iterate for PureUnary.
+func PureIterate[T any](seed T, u PureUnary[T]) PureSupplier[T] {
+	return Iterate(seed, u.Lift()).Pure()
+}
+
+// PureGenerate is Generate for PureFunc.
+func PureGenerate[T any](f PureFunc[int, T]) PureSupplier[T] {
+	return Generate(f.Lift()).Pure()
+}
+
+// PureTake is Take for PureSupplier.
+func PureTake[T any](s PureSupplier[T], n int) PureSupplier[[]T] {
+	return Take(s.Lift(), n).Pure()
+}
+
+// PureCycle is Cycle for PureSupplier.
+func PureCycle[T any](vs ...T) PureSupplier[T] {
+	return Cycle(vs...).Pure()
+}
+
+// PureConstant is Constant for PureSupplier.
+func PureConstant[T any](v T) PureSupplier[T] {
+	return Constant(v).Pure()
+}
+
+// PureFailing is Failing for PureSupplier.
+func PureFailing[T any](err error) PureSupplier[T] {
+	return Failing[T](err).Pure()
+}
+
+// PureMustMapSupplier is MapSupplier for PureMustSupplier.
+func PureMustMapSupplier[T, R any](s PureMustSupplier[T], f PureMustFunc[T, R]) PureMustSupplier[R] {
+	return MapSupplier(s.Lift(), f.Lift()).Must().Pure()
+}
+
+// PureMustZipSuppliers is ZipSuppliers for PureMustSupplier.
+func PureMustZipSuppliers[T, U any](first PureMustSupplier[T], second PureMustSupplier[U]) PureMustSupplier[Pair[T, U]] {
+	return ZipSuppliers(first.Lift(), second.Lift()).Must().Pure()
+}
+
+// PureMustIterate is Iterate for PureMustUnary.
+func PureMustIterate[T any](seed T, u PureMustUnary[T]) PureMustSupplier[T] {
+	return Iterate(seed, u.Lift()).Must().Pure()
+}
+
+// PureMustGenerate is Generate for PureMustFunc.
+func PureMustGenerate[T any](f PureMustFunc[int, T]) PureMustSupplier[T] {
+	return Generate(f.Lift()).Must().Pure()
+}
+
+// PureMustTake is Take for PureMustSupplier.
+func PureMustTake[T any](s PureMustSupplier[T], n int) PureMustSupplier[[]T] {
+	return Take(s.Lift(), n).Must().Pure()
+}
+
+// PureMustCycle is Cycle for PureMustSupplier.
+func PureMustCycle[T any](vs ...T) PureMustSupplier[T] {
+	return Cycle(vs...).Must().Pure()
+}
+
+// PureMustConstant is Constant for PureMustSupplier.
+func PureMustConstant[T any](v T) PureMustSupplier[T] {
+	return Constant(v).Must().Pure()
+}
@@ -0,0 +1,158 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Supplier combinators", func() {
+	one := funk.Supplier[int](func(ctx context.Context) (context.Context, int, error) {
+		return incCtxValue(ctx), 1, nil
+	})
+	fail := funk.Supplier[int](func(ctx context.Context) (context.Context, int, error) {
+		return incCtxValue(ctx), 0, errors.New("fail")
+	})
+
+	Describe("MapSupplier", func() {
+		It("should apply the func to the supplied result", func() {
+			s := funk.MapSupplier(one, funk.PureMustFunc[int, string](strconv.Itoa).Lift())
+			ctx, v, err := s(context.Background())
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal("1"))
+			Expect(getCtxValue(ctx)).To(Equal(1))
+		})
+		It("should not apply the func if the supplier returns error", func() {
+			applied := false
+			s := funk.MapSupplier(fail, funk.Func[int, int](func(ctx context.Context, i int) (context.Context, int, error) {
+				applied = true
+				return ctx, i, nil
+			}))
+			_, _, err := s(context.Background())
+			Expect(err).To(HaveOccurred())
+			Expect(applied).To(BeFalse())
+		})
+	})
+
+	Describe("ZipSuppliers", func() {
+		It("should supply both results", func() {
+			ctx, p, err := funk.ZipSuppliers(one, funk.Constant("a"))(context.Background())
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(p).To(Equal(funk.Pair[int, string]{First: 1, Second: "a"}))
+			Expect(getCtxValue(ctx)).To(Equal(1))
+		})
+		It("should return the error of either supplier", func() {
+			_, _, err := funk.ZipSuppliers(one, funk.Failing[string](errors.New("")))(context.Background())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("FirstSuccessful", func() {
+		It("should supply the first successful result", func() {
+			ctx, v, err := funk.FirstSuccessful(fail, one, fail)(context.Background())
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal(1))
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
+		It("should return the joined errors if all fail", func() {
+			e1, e2 := errors.New("1"), errors.New("2")
+			_, _, err := funk.FirstSuccessful(funk.Failing[int](e1), funk.Failing[int](e2))(context.Background())
+			Expect(err).To(MatchError(e1))
+			Expect(err).To(MatchError(e2))
+		})
+		It("should return ErrNoSupplier without suppliers", func() {
+			_, _, err := funk.FirstSuccessful[int]()(context.Background())
+			Expect(err).To(MatchError(funk.ErrNoSupplier))
+		})
+		It("should work for PureSupplier", func() {
+			v, err := funk.PureFirstSuccessful(funk.PureFailing[int](errors.New("")), funk.PureConstant(2))()
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal(2))
+		})
+	})
+
+	Describe("OrElse", func() {
+		It("should supply the value on error", func() {
+			_, v, err := fail.OrElse(5)(context.Background())
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal(5))
+			_, v, _ = one.OrElse(5)(context.Background())
+			Expect(v).To(Equal(1))
+		})
+		It("should work for PureSupplier", func() {
+			v, err := funk.PureFailing[int](errors.New("")).OrElse(5)()
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(v).To(Equal(5))
+		})
+	})
+
+	Describe("Iterate", func() {
+		It("should supply the sequence starting with the seed", func() {
+			s := funk.PureMustIterate(1, func(i int) int { return i * 2 })
+			Expect(funk.PureMustTake(s, 5)()).To(Equal([]int{1, 2, 4, 8, 16}))
+			Expect(s()).To(Equal(32))
+		})
+		It("should not advance on errors", func() {
+			failing := true
+			s := funk.PureIterate(1, func(i int) (int, error) {
+				if failing {
+					return 0, errors.New("")
+				}
+				return i + 1, nil
+			})
+			Expect(s()).To(Equal(1))
+			_, err := s()
+			Expect(err).To(HaveOccurred())
+			failing = false
+			Expect(s()).To(Equal(2))
+		})
+	})
+
+	Describe("Generate", func() {
+		It("should supply the results of the indexes", func() {
+			s := funk.PureMustGenerate(func(i int) string { return strconv.Itoa(i * i) })
+			Expect(funk.PureMustTake(s, 4)()).To(Equal([]string{"0", "1", "4", "9"}))
+		})
+	})
+
+	Describe("Take", func() {
+		It("should stop on the first error", func() {
+			s := funk.Generate(funk.Func[int, int](func(ctx context.Context, i int) (context.Context, int, error) {
+				if i == 2 {
+					return ctx, 0, errors.New("")
+				}
+				return incCtxValue(ctx), i, nil
+			}))
+			ctx, vs, err := funk.Take(s, 5)(context.Background())
+			Expect(err).To(HaveOccurred())
+			Expect(vs).To(Equal([]int{0, 1}))
+			Expect(getCtxValue(ctx)).To(Equal(2))
+		})
+	})
+
+	Describe("Cycle", func() {
+		It("should supply the values over and over", func() {
+			Expect(funk.PureMustTake(funk.PureMustCycle("a", "b"), 5)()).To(Equal([]string{"a", "b", "a", "b", "a"}))
+		})
+		It("should panic without values", func() {
+			Expect(func() { funk.Cycle[int]() }).To(Panic())
+		})
+	})
+
+	Describe("Constant and Failing", func() {
+		It("should always supply the same result", func() {
+			Expect(funk.PureMustConstant(3)()).To(Equal(3))
+			ctx, v := funk.MustConstant(3)(context.Background())
+			Expect(v).To(Equal(3))
+			Expect(ctx).To(Equal(context.Background()))
+			e := errors.New("")
+			_, err := funk.PureFailing[int](e)()
+			Expect(err).To(Equal(e))
+		})
+	})
+})
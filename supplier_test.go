@@ -120,3 +120,28 @@ var _ = Describe("MustSupplier", func() {
 		})
 	})
 })
+
+var _ = Describe("Lifting suppliers", func() {
+	It("should lift MustSupplier to Supplier", func() {
+		s := funk.MustSupplier[string](func(ctx context.Context) (context.Context, string) {
+			return context.WithValue(ctx, "k", 1), "1"
+		}).Lift()
+		ctx, v, err := s(context.Background())
+		Expect(ctx.Value("k")).To(Equal(1))
+		Expect(v).To(Equal("1"))
+		Expect(err).To(Not(HaveOccurred()))
+	})
+	It("should lift PureSupplier to Supplier", func() {
+		s := funk.PureSupplier[string](func() (string, error) {
+			return "", errors.New("")
+		}).Lift()
+		ctx, _, err := s(context.WithValue(context.Background(), "k", 1))
+		Expect(ctx.Value("k")).To(Equal(1))
+		Expect(err).To(HaveOccurred())
+	})
+	It("should lift PureMustSupplier to Supplier", func() {
+		_, v, err := funk.PureMustSupplier[string](func() string { return "1" }).Lift()(context.Background())
+		Expect(v).To(Equal("1"))
+		Expect(err).To(Not(HaveOccurred()))
+	})
+})
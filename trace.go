@@ -0,0 +1,159 @@
+package funk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tracer opens spans stored in contexts, so the spans opened with the contexts threaded through a composition form
+// a trace tree. The otelfunk package adapts OpenTelemetry tracers.
+type Tracer interface {
+	// Start opens a span as a child of the span carried by ctx, and returns a context carrying the new span.
+	Start(ctx context.Context, name string) (context.Context, Span)
+	// Resume returns a context that carries the span carried by from instead of the span carried by ctx, it's used
+	// to close the scope of a span in the context returned by the traced function.
+	Resume(ctx, from context.Context) context.Context
+}
+
+// Span represents a traced call.
+type Span interface {
+	// End ends the span, err is the error of the call.
+	End(err error)
+}
+
+// Traced returns a Middleware that opens a span named name for each call with t. If name is empty, the span is named
+// after the stage path of the context, or the kind of the call outside named stages.
+// The context returned by the call carries the span of the enclosing call again, so the calls composed after it open
+// sibling spans rather than child spans. If the call panics, the span is ended with an error before the panic goes on.
+func Traced(t Tracer, name string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, inv *Invocation) (context.Context, any, error) {
+			spanName := name
+			if spanName == "" {
+				if spanName = StagePath(ctx); spanName == "" {
+					spanName = string(inv.Kind)
+				}
+			}
+			parent := ctx
+			ctx, span := t.Start(ctx, spanName)
+			defer func() {
+				if r := recover(); r != nil {
+					span.End(fmt.Errorf("%w: %v", errPanicked, r))
+					panic(r)
+				}
+			}()
+			ctx, r, err := next(ctx, inv)
+			span.End(err)
+			if ctx != nil {
+				ctx = t.Resume(ctx, parent)
+			}
+			return ctx, r, err
+		}
+	}
+}
+
+// RecordedSpan represents a span recorded by SpanRecorder.
+type RecordedSpan struct {
+	Name     string
+	Started  time.Time
+	Finished time.Time
+	Err      error
+	Parent   *RecordedSpan
+	Children []*RecordedSpan
+
+	recorder *SpanRecorder
+}
+
+// End implements Span.
+func (s *RecordedSpan) End(err error) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	if s.Finished.IsZero() {
+		s.Finished, s.Err = time.Now(), err
+	}
+}
+
+// Ended reports whether the span is ended.
+func (s *RecordedSpan) Ended() bool {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	return !s.Finished.IsZero()
+}
+
+// SpanRecorder is a Tracer that records spans in memory, it's intended for tests. It's safe for concurrent use.
+type SpanRecorder struct {
+	mu    sync.Mutex
+	roots []*RecordedSpan
+}
+
+// NewSpanRecorder returns an empty SpanRecorder.
+func NewSpanRecorder() *SpanRecorder {
+	return &SpanRecorder{}
+}
+
+type recordedSpanKey struct{}
+
+// Start implements Tracer.
+func (r *SpanRecorder) Start(ctx context.Context, name string) (context.Context, Span) {
+	s := &RecordedSpan{Name: name, Started: time.Now(), recorder: r}
+	r.mu.Lock()
+	if parent, _ := ctx.Value(recordedSpanKey{}).(*RecordedSpan); parent != nil && parent.recorder == r {
+		s.Parent = parent
+		parent.Children = append(parent.Children, s)
+	} else {
+		r.roots = append(r.roots, s)
+	}
+	r.mu.Unlock()
+	return context.WithValue(ctx, recordedSpanKey{}, s), s
+}
+
+// Resume implements Tracer.
+func (r *SpanRecorder) Resume(ctx, from context.Context) context.Context {
+	s, _ := from.Value(recordedSpanKey{}).(*RecordedSpan)
+	return context.WithValue(ctx, recordedSpanKey{}, s)
+}
+
+// Roots returns the recorded spans that have no parent, in the order they started.
+func (r *SpanRecorder) Roots() []*RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*RecordedSpan(nil), r.roots...)
+}
+
+// Reset forgets all recorded spans.
+func (r *SpanRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.roots = nil
+}
+
+// String renders the recorded spans as indented text, one span per line, e.g. "validate (error: boom)".
+func (r *SpanRecorder) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var b strings.Builder
+	for _, s := range r.roots {
+		s.write(&b, 0)
+	}
+	return b.String()
+}
+
+func (s *RecordedSpan) write(b *strings.Builder, depth int) {
+	b.WriteString(strings.Repeat("  ", depth))
+	b.WriteString(s.Name)
+	switch {
+	case s.Finished.IsZero():
+		b.WriteString(" (not ended)")
+	case s.Err != nil:
+		b.WriteString(" (error: ")
+		b.WriteString(s.Err.Error())
+		b.WriteString(")")
+	}
+	b.WriteString("\n")
+	for _, c := range s.Children {
+		c.write(b, depth+1)
+	}
+}
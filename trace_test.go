@@ -0,0 +1,75 @@
+package funk_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Tracing", func() {
+	var recorder *funk.SpanRecorder
+	BeforeEach(func() {
+		recorder = funk.NewSpanRecorder()
+	})
+
+	inc := funk.Unary[int](func(ctx context.Context, i int) (context.Context, int, error) {
+		return incCtxValue(ctx), i + 1, nil
+	})
+
+	It("should record nested compositions as a tree", func() {
+		traced := func(name string, u funk.Unary[int]) funk.Unary[int] {
+			return u.With(funk.Traced(recorder, name))
+		}
+		normalize := traced("normalize", traced("trim", inc).Then(traced("lower", inc)))
+		failing := traced("validate", func(ctx context.Context, i int) (context.Context, int, error) {
+			return ctx, i, errors.New("boom")
+		})
+		u := traced("ingest", normalize.Then(failing))
+		ctx, _, err := u(context.Background(), 0)
+		Expect(err).To(HaveOccurred())
+		Expect(getCtxValue(ctx)).To(Equal(2))
+		Expect(recorder.String()).To(Equal(`ingest (error: boom)
+  normalize
+    trim
+    lower
+  validate (error: boom)
+`))
+		roots := recorder.Roots()
+		Expect(roots).To(HaveLen(1))
+		Expect(roots[0].Ended()).To(BeTrue())
+		Expect(roots[0].Children[1].Parent).To(BeIdenticalTo(roots[0]))
+		Expect(roots[0].Finished).To(Not(BeTemporally("<", roots[0].Started)))
+	})
+
+	It("should name spans after stages or kinds", func() {
+		c := funk.Consumer[int](func(ctx context.Context, i int) (context.Context, error) {
+			return ctx, nil
+		})
+		_, _ = c.With(funk.Traced(recorder, "")).Stage("send")(context.Background(), 1)
+		_, _, _ = funk.Eq(1).Lift().With(funk.Traced(recorder, ""))(context.Background(), 1)
+		Expect(recorder.String()).To(Equal("send\nPredicate\n"))
+		recorder.Reset()
+		Expect(recorder.Roots()).To(BeEmpty())
+	})
+
+	It("should record spans of separate calls as separate trees", func() {
+		u := inc.With(funk.Traced(recorder, "inc"))
+		ctx, _, _ := u(context.Background(), 0)
+		_, _, _ = u(ctx, 0)
+		Expect(recorder.Roots()).To(HaveLen(2))
+	})
+	It("should end the span of a call that panics", func() {
+		u := funk.Unary[int](func(ctx context.Context, i int) (context.Context, int, error) {
+			panic("boom")
+		}).With(funk.Traced(recorder, "explode"))
+		Expect(func() { _, _, _ = u(context.Background(), 0) }).To(PanicWith("boom"))
+		roots := recorder.Roots()
+		Expect(roots).To(HaveLen(1))
+		Expect(roots[0].Ended()).To(BeTrue())
+		Expect(recorder.String()).To(Equal("explode (error: panic: boom)\n"))
+	})
+})
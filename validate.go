@@ -0,0 +1,185 @@
+package funk
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// FieldError reports a rule violated by a field.
+type FieldError struct {
+	// Field is the path of the field, e.g. address.zip or items[0].name, it's empty for the validated value itself.
+	Field string `json:"field"`
+	// Message is the message of the violated rule.
+	Message string `json:"message"`
+}
+
+// ValidationErrors is returned by Validator if any rule is violated, it's encoded as a JSON array of FieldError.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		if fe.Field == "" {
+			msgs[i] = fe.Message
+		} else {
+			msgs[i] = fe.Field + ": " + fe.Message
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Rule represents a validation rule of Validator.
+type Rule[T any] struct {
+	check func(ctx context.Context, t T, path string) (context.Context, ValidationErrors, error)
+}
+
+// MessageData is the data of the message templates of rules.
+type MessageData struct {
+	// Field is the path of the validated field.
+	Field string
+	// Value is the validated value.
+	Value any
+}
+
+// Check returns a Rule that is violated if p evaluates to false. The message is a text/template executed with
+// MessageData, e.g. "{{.Value}} is not a valid email", and the violation is reported on the field path, which is
+// relative to the enclosing rules. It panics if the message is not a valid template.
+func Check[T any](path string, p Predicate[T], message string) Rule[T] {
+	tmpl := template.Must(template.New(path).Parse(message))
+	return Rule[T]{check: func(ctx context.Context, t T, parent string) (context.Context, ValidationErrors, error) {
+		ctx, ok, err := p(ctx, t)
+		if err != nil || ok {
+			return ctx, nil, err
+		}
+		field := joinFieldPath(parent, path)
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, MessageData{Field: field, Value: t}); err != nil {
+			return ctx, nil, fmt.Errorf("message of %s: %w", field, err)
+		}
+		return ctx, ValidationErrors{{Field: field, Message: sb.String()}}, nil
+	}}
+}
+
+// When returns a Rule that checks this rule only if cond evaluates to true.
+func (r Rule[T]) When(cond Predicate[T]) Rule[T] {
+	return Rule[T]{check: func(ctx context.Context, t T, path string) (context.Context, ValidationErrors, error) {
+		ctx, ok, err := cond(ctx, t)
+		if err != nil || !ok {
+			return ctx, nil, err
+		}
+		return r.check(ctx, t, path)
+	}}
+}
+
+// Field returns a Rule that checks rules on the field got by get, the paths of the rules are nested in path.
+// Nested validators can be checked by their Rule.
+func Field[T, F any](path string, get Func[T, F], rules ...Rule[F]) Rule[T] {
+	return Rule[T]{check: func(ctx context.Context, t T, parent string) (context.Context, ValidationErrors, error) {
+		ctx, f, err := get(ctx, t)
+		if err != nil {
+			return ctx, nil, err
+		}
+		return checkRules(ctx, f, joinFieldPath(parent, path), rules)
+	}}
+}
+
+// Each returns a Rule that checks rules on each element of a slice, the paths of the rules are nested in the path of
+// the element, e.g. items[0].
+func Each[S ~[]E, E any](rules ...Rule[E]) Rule[S] {
+	return Rule[S]{check: func(ctx context.Context, s S, path string) (context.Context, ValidationErrors, error) {
+		var errs ValidationErrors
+		for i, e := range s {
+			var ves ValidationErrors
+			var err error
+			ctx, ves, err = checkRules(ctx, e, fmt.Sprintf("%s[%d]", path, i), rules)
+			if err != nil {
+				return ctx, nil, err
+			}
+			errs = append(errs, ves...)
+		}
+		return ctx, errs, nil
+	}}
+}
+
+// EachValue returns a Rule that checks rules on each value of a map in order of keys, the paths of the rules are
+// nested in the path of the value, e.g. labels[env].
+func EachValue[M ~map[K]V, K cmp.Ordered, V any](rules ...Rule[V]) Rule[M] {
+	return Rule[M]{check: func(ctx context.Context, m M, path string) (context.Context, ValidationErrors, error) {
+		keys := make([]K, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		var errs ValidationErrors
+		for _, k := range keys {
+			var ves ValidationErrors
+			var err error
+			ctx, ves, err = checkRules(ctx, m[k], fmt.Sprintf("%s[%v]", path, k), rules)
+			if err != nil {
+				return ctx, nil, err
+			}
+			errs = append(errs, ves...)
+		}
+		return ctx, errs, nil
+	}}
+}
+
+// Validator validates values by rules, reporting all violations as ValidationErrors.
+type Validator[T any] struct {
+	rules []Rule[T]
+}
+
+// NewValidator returns a Validator of rules.
+func NewValidator[T any](rules ...Rule[T]) *Validator[T] {
+	return &Validator[T]{rules: rules}
+}
+
+// Validate checks all rules in order on t, threading the context through the rules. It returns ValidationErrors if
+// any rule is violated, or the error of the first rule that fails to be checked.
+func (v *Validator[T]) Validate(ctx context.Context, t T) (context.Context, error) {
+	ctx, errs, err := checkRules(ctx, t, "", v.rules)
+	if err != nil {
+		return ctx, err
+	}
+	if len(errs) > 0 {
+		return ctx, errs
+	}
+	return ctx, nil
+}
+
+// Consumer returns a Consumer that performs Validate.
+func (v *Validator[T]) Consumer() Consumer[T] {
+	return v.Validate
+}
+
+// Rule returns a Rule that checks the rules of this validator, e.g. as a nested validator by Field.
+func (v *Validator[T]) Rule() Rule[T] {
+	return Rule[T]{check: func(ctx context.Context, t T, path string) (context.Context, ValidationErrors, error) {
+		return checkRules(ctx, t, path, v.rules)
+	}}
+}
+
+func checkRules[T any](ctx context.Context, t T, path string, rules []Rule[T]) (context.Context, ValidationErrors, error) {
+	var errs ValidationErrors
+	for _, r := range rules {
+		var ves ValidationErrors
+		var err error
+		ctx, ves, err = r.check(ctx, t, path)
+		if err != nil {
+			return ctx, nil, err
+		}
+		errs = append(errs, ves...)
+	}
+	return ctx, errs, nil
+}
+
+func joinFieldPath(parent, path string) string {
+	if parent == "" || path == "" {
+		return parent + path
+	}
+	return parent + "." + path
+}
@@ -0,0 +1,136 @@
+package funk_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	funk "github.com/hongcankun/gofunk"
+)
+
+var _ = Describe("Validator", func() {
+	type address struct {
+		country string
+		zip     string
+	}
+	type item struct {
+		name     string
+		quantity int
+	}
+	type order struct {
+		email    string
+		address  address
+		items    []item
+		labels   map[string]string
+		business bool
+		vat      string
+	}
+
+	notEmpty := funk.Ne("").Lift()
+	addressValidator := funk.NewValidator(
+		funk.Check("country", funk.PureMustPredicate[address](func(a address) bool {
+			return a.country != ""
+		}).Lift(), "is required"),
+		funk.Field("zip", funk.PureMustFunc[address, string](func(a address) string { return a.zip }).Lift(),
+			funk.Check("", funk.LenBetween(5, 5).Lift(), "{{printf \"%q\" .Value}} must have 5 characters"),
+		),
+	)
+	v := funk.NewValidator(
+		funk.Field("email", funk.PureMustFunc[order, string](func(o order) string { return o.email }).Lift(),
+			funk.Check("", funk.Contains("@").Lift(), "{{.Value}} is not a valid email"),
+		),
+		funk.Field("address", funk.PureMustFunc[order, address](func(o order) address { return o.address }).Lift(),
+			addressValidator.Rule(),
+		),
+		funk.Field("items", funk.PureMustFunc[order, []item](func(o order) []item { return o.items }).Lift(),
+			funk.Check("", funk.IsEmpty[[]item]().Not().Lift(), "must not be empty"),
+			funk.Each[[]item](
+				funk.Field("name", funk.PureMustFunc[item, string](func(i item) string { return i.name }).Lift(),
+					funk.Check("", notEmpty, "is required"),
+				),
+				funk.Field("quantity", funk.PureMustFunc[item, int](func(i item) int { return i.quantity }).Lift(),
+					funk.Check("", funk.Gt(0).Lift(), "{{.Field}} must be positive"),
+				),
+			),
+		),
+		funk.Field("labels", funk.PureMustFunc[order, map[string]string](func(o order) map[string]string {
+			return o.labels
+		}).Lift(),
+			funk.EachValue[map[string]string](funk.Check("", notEmpty, "must not be empty")),
+		),
+		funk.Check("vat", funk.PureMustPredicate[order](func(o order) bool { return o.vat != "" }).Lift(),
+			"is required for businesses").
+			When(funk.PureMustPredicate[order](func(o order) bool { return o.business }).Lift()),
+	)
+	valid := order{
+		email:   "a@b.c",
+		address: address{country: "DE", zip: "10115"},
+		items:   []item{{name: "book", quantity: 1}},
+	}
+
+	It("should accept valid values", func() {
+		_, err := v.Validate(context.Background(), valid)
+		Expect(err).To(Not(HaveOccurred()))
+	})
+
+	It("should report all violations with field paths", func() {
+		o := order{
+			email:    "nobody",
+			address:  address{zip: "123"},
+			items:    []item{{name: "book", quantity: 1}, {quantity: -1}},
+			labels:   map[string]string{"team": "", "env": ""},
+			business: true,
+		}
+		_, err := v.Consumer()(context.Background(), o)
+		var ves funk.ValidationErrors
+		Expect(errors.As(err, &ves)).To(BeTrue())
+		Expect(ves).To(Equal(funk.ValidationErrors{
+			{Field: "email", Message: "nobody is not a valid email"},
+			{Field: "address.country", Message: "is required"},
+			{Field: "address.zip", Message: `"123" must have 5 characters`},
+			{Field: "items[1].name", Message: "is required"},
+			{Field: "items[1].quantity", Message: "items[1].quantity must be positive"},
+			{Field: "labels[env]", Message: "must not be empty"},
+			{Field: "labels[team]", Message: "must not be empty"},
+			{Field: "vat", Message: "is required for businesses"},
+		}))
+		Expect(err.Error()).To(HavePrefix("email: nobody is not a valid email; address.country: is required; "))
+	})
+
+	It("should skip conditional rules", func() {
+		_, err := v.Validate(context.Background(), order{email: "a@b.c", address: valid.address})
+		Expect(err).To(MatchError("items: must not be empty"))
+	})
+
+	It("should be encoded as JSON", func() {
+		_, err := v.Validate(context.Background(), order{email: "a@b.c", address: valid.address})
+		b, jerr := json.Marshal(err)
+		Expect(jerr).To(Not(HaveOccurred()))
+		Expect(b).To(MatchJSON(`[{"field":"items","message":"must not be empty"}]`))
+	})
+
+	It("should return errors of rules and thread the context", func() {
+		errBoom := errors.New("boom")
+		inc := funk.Predicate[int](func(ctx context.Context, i int) (context.Context, bool, error) {
+			return incCtxValue(ctx), false, nil
+		})
+		ctx, err := funk.NewValidator(funk.Check("", inc, "first"), funk.Check("", inc, "second")).
+			Validate(context.Background(), 1)
+		Expect(err).To(MatchError("first; second"))
+		Expect(getCtxValue(ctx)).To(Equal(2))
+
+		failing := funk.Predicate[int](func(ctx context.Context, i int) (context.Context, bool, error) {
+			return ctx, false, errBoom
+		})
+		_, err = funk.NewValidator(funk.Check("x", inc, "invalid"), funk.Check("y", failing, "invalid")).
+			Validate(context.Background(), 1)
+		Expect(err).To(Equal(errBoom))
+	})
+
+	It("should panic on invalid message templates", func() {
+		Expect(func() { funk.Check("x", notEmpty, "{{") }).To(Panic())
+	})
+})